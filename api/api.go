@@ -1,5 +1,15 @@
+// Package api holds the types and method params of the Telegram Bot API.
+//
+// Most of them are generated from spec/botapi.json, which covers the
+// subset of Bot API 7.0 this library supports rather than the whole API:
+// the updates, messages and chat management a bot typically needs.
+// Types carry the fields the library decodes, and only methods listed in
+// the spec have a params struct and a TgramBot wrapper. Methods outside
+// it can still be called with TgramBot.Call and a params struct of your own.
 package api
 
+//go:generate go run ../cmd/tgramgen -spec spec/botapi.json -types types_gen.go -params params_gen.go -methods ../pkg/bot/methods_gen.go
+
 import (
 	"fmt"
 	"os"
@@ -11,7 +21,19 @@ const (
 )
 
 func MakeEndpointStr(resource, key string) string {
-	return fmt.Sprintf("%s/bot%s/%s", BaseURL, key, resource)
+	return MakeEndpointStrWithBase(BaseURL, resource, key)
+}
+
+func MakeEndpointStrWithBase(baseURL, resource, key string) string {
+	return fmt.Sprintf("%s/bot%s/%s", trimBaseURL(baseURL), key, resource)
+}
+
+func MakeTestEndpointStrWithBase(baseURL, resource, key string) string {
+	return fmt.Sprintf("%s/bot%s/test/%s", trimBaseURL(baseURL), key, resource)
+}
+
+func trimBaseURL(baseURL string) string {
+	return strings.TrimSuffix(baseURL, "/")
 }
 
 func GetAPIKey(path string) (string, error) {
@@ -0,0 +1,9 @@
+package api
+
+// ChatID identifies the target chat of a method, documented by the
+// Bot API as "Integer or String": either the chat's unique identifier,
+// an int64, or the @username of a channel or supergroup, a string.
+//
+//	params := SendMessageParams{ChatID: msg.Chat.Id, Text: "hi"}
+//	params = SendMessageParams{ChatID: "@channelusername", Text: "hi"}
+type ChatID interface{}
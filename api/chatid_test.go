@@ -0,0 +1,29 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestChatIDForms(t *testing.T) {
+	tests := []struct {
+		chatID ChatID
+		want   string
+	}{
+		{int64(-1001234567890), `-1001234567890`},
+		{"@channelusername", `"@channelusername"`},
+	}
+	for _, tt := range tests {
+		body, err := json.Marshal(SendMessageParams{ChatID: tt.chatID, Text: "hi"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var params map[string]json.RawMessage
+		if err := json.Unmarshal(body, &params); err != nil {
+			t.Fatal(err)
+		}
+		if got := string(params["chat_id"]); got != tt.want {
+			t.Errorf("chat_id = %s, want %s", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Error is an unsuccessful response from the Bot API.
+// Code mirrors the HTTP status returned by Telegram
+// and Description is its human readable explanation.
+type Error struct {
+	Code        int
+	Description string
+	Parameters  *ResponseParameters
+}
+
+func (e *Error) Error() string {
+	if e.Description == "" {
+		return "response was not Ok"
+	}
+	return fmt.Sprintf("telegram: %s (%d)", e.Description, e.Code)
+}
+
+// RetryAfter returns how long Telegram asked us to wait
+// before repeating a request that hit a flood limit, or 0.
+func (e *Error) RetryAfter() time.Duration {
+	if e.Parameters == nil {
+		return 0
+	}
+	return time.Duration(e.Parameters.RetryAfter) * time.Second
+}
+
+// AsError unwraps err into an *Error if it came from the Bot API.
+func AsError(err error) (*Error, bool) {
+	var apiErr *Error
+	ok := errors.As(err, &apiErr)
+	return apiErr, ok
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+)
+
+// Size limits imposed by the Bot API on file transfers.
+// A self-hosted telegram-bot-api server in --local mode
+// lifts the download limit and raises the upload limit.
+const (
+	MaxUploadSize      int64 = 50 << 20
+	MaxDownloadSize    int64 = 20 << 20
+	LocalMaxUploadSize int64 = 2000 << 20
+)
+
+// InputFile is a file passed to a Bot API method.
+// Exactly one of FileID, URL, Path or Reader should be set.
+// FileID and URL reference files Telegram already knows about or can fetch.
+// Path names a file on disk, which is uploaded, or passed by path
+// when the bot talks to a local Bot API server.
+// Reader streams an upload, and Name is the file name sent with it.
+type InputFile struct {
+	FileID string
+	URL    string
+	Path   string
+	Reader io.Reader
+	Name   string
+
+	// attach is the multipart part the file is uploaded in, set by AttachedAs.
+	attach string
+}
+
+// FileID references a file already stored on Telegram's servers.
+func FileID(id string) *InputFile {
+	return &InputFile{FileID: id}
+}
+
+// FileURL references a file Telegram should download from the web.
+func FileURL(url string) *InputFile {
+	return &InputFile{URL: url}
+}
+
+// FilePath references a file on the local disk.
+func FilePath(path string) *InputFile {
+	return &InputFile{Path: path, Name: filepath.Base(path)}
+}
+
+// FileReader uploads the contents of r under the given file name.
+func FileReader(name string, r io.Reader) *InputFile {
+	return &InputFile{Reader: r, Name: name}
+}
+
+// NeedsUpload reports whether the file has to be sent as multipart data.
+// Paths only need uploading when local is false.
+func (f *InputFile) NeedsUpload(local bool) bool {
+	return f.Reader != nil || (f.Path != "" && !local)
+}
+
+// AttachedAs returns a copy of the file that is uploaded in the multipart
+// part named name, so that it marshals as "attach://name".
+// The bot calls it for every file it uploads; f itself is left unchanged.
+func (f *InputFile) AttachedAs(name string) *InputFile {
+	attached := *f
+	attached.attach = name
+	return &attached
+}
+
+// MarshalJSON encodes the file as the string Telegram expects:
+// a file_id, a URL, an attach:// reference for uploads,
+// or a file:// URI for paths passed to a local Bot API server.
+func (f *InputFile) MarshalJSON() ([]byte, error) {
+	switch {
+	case f.FileID != "":
+		return json.Marshal(f.FileID)
+	case f.URL != "":
+		return json.Marshal(f.URL)
+	case f.attach != "":
+		return json.Marshal("attach://" + f.attach)
+	case f.Reader != nil:
+		return json.Marshal("attach://" + f.Name)
+	case f.Path != "":
+		abs, err := filepath.Abs(f.Path)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal("file://" + filepath.ToSlash(abs))
+	default:
+		return nil, fmt.Errorf("input file has no source")
+	}
+}
+
+func MakeFileEndpointStrWithBase(baseURL, filePath, key string) string {
+	return fmt.Sprintf("%s/file/bot%s/%s", trimBaseURL(baseURL), key, filePath)
+}
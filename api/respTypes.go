@@ -1,591 +0,0 @@
-package api
-
-import (
-	"encoding/json"
-	"fmt"
-)
-
-type Response struct {
-	Ok     bool            `json:"ok"`
-	Result json.RawMessage `json:"result"`
-}
-
-func (resp *Response) Unwrap() (json.RawMessage, error) {
-	if resp.Ok {
-		return resp.Result, nil
-	}
-	return resp.Result, fmt.Errorf("response was not Ok")
-}
-
-type User struct {
-	Id                      int64  `json:"id,omitempty"`
-	IsBot                   bool   `json:"is_bot,omitempty"`
-	FirstName               string `json:"first_name,omitempty"`
-	LastName                string `json:"last_name,omitempty"`
-	Username                string `json:"username,omitempty"`
-	LanguageCode            string `json:"language_code,omitempty"`
-	IsPremium               bool   `json:"is_premium,omitempty"`
-	AddedToAttachmentMenu   bool   `json:"added_to_attachment_menu,omitempty"`
-	CanJoinGroups           bool   `json:"can_join_groups,omitempty"`
-	CanReadAllGroupMessages bool   `json:"can_read_all_group_messages,omitempty"`
-	SupportsInlineQueries   bool   `json:"supports_inline_queries,omitempty"`
-}
-
-type Update struct {
-	UpdateId           int64               `json:"update_id,omitempty"`
-	Message            *Message            `json:"message"`
-	EditedMessage      *Message            `json:"edited_message,omitempty"`
-	ChannelPost        *Message            `json:"channel_post,omitempty"`
-	EditedChannelPost  *Message            `json:"edited_channel_post,omitempty"`
-	InlineQuery        *InlineQuery        `json:"inline_query,omitempty"`
-	ChosenInlineResult *ChosenInlineResult `json:"chosen_inline_result,omitempty"`
-	CallbackQuery      *CallbackQuery      `json:"callback_query,omitempty"`
-	ShippingQuery      *ShippingQuery      `json:"shipping_query,omitempty"`
-	PreCheckoutQuery   *PreCheckoutQuery   `json:"pre_checkout_query,omitempty"`
-	Poll               *Poll               `json:"poll,omitempty"`
-	PollAnswer         *PollAnswer         `json:"poll_answer,omitempty"`
-	MyChatMember       *ChatMemberUpdated  `json:"my_chat_member,omitempty"`
-	ChatMember         *ChatMemberUpdated  `json:"chat_member,omitempty"`
-	ChatJoinRequest    *ChatJoinRequest    `json:"chat_join_request,omitempty"`
-}
-
-type Message struct {
-	MessageID                     int                            `json:"message_id"`
-	MessageThreadId               int                            `json:"message_thread_id,omitempty"`
-	From                          *User                          `json:"from,omitempty"`
-	SenderChat                    *Chat                          `json:"sender_chat,omitempty"`
-	Date                          int                            `json:"date"`
-	Chat                          *Chat                          `json:"chat"`
-	ForwardFrom                   *User                          `json:"forward_from,omitempty"`
-	ForwardFromChat               *Chat                          `json:"forward_from_chat,omitempty"`
-	ForwardFromMessageID          int                            `json:"forward_from_message_id,omitempty"`
-	ForwardSignature              string                         `json:"forward_signature,omitempty"`
-	ForwardSenderName             string                         `json:"forward_sender_name,omitempty"`
-	ForwardDate                   int                            `json:"forward_date,omitempty"`
-	IsTopicMessage                bool                           `json:"is_topic_message,omitempty"`
-	IsAutomaticForward            bool                           `json:"is_automatic_forward,omitempty"`
-	ReplyToMessage                *Message                       `json:"reply_to_message,omitempty"`
-	ViaBot                        *User                          `json:"via_bot,omitempty"`
-	EditDate                      int                            `json:"edit_date,omitempty"`
-	HasProtectedContent           bool                           `json:"has_protected_content,omitempty"`
-	MediaGroupID                  string                         `json:"media_group_id,omitempty"`
-	AuthorSignature               string                         `json:"author_signature,omitempty"`
-	Text                          string                         `json:"text,omitempty"`
-	Entities                      []MessageEntity                `json:"entities,omitempty"`
-	Animation                     *Animation                     `json:"animation,omitempty"`
-	Audio                         *Audio                         `json:"audio,omitempty"`
-	Document                      *Document                      `json:"document,omitempty"`
-	Photo                         []PhotoSize                    `json:"photo,omitempty"`
-	Sticker                       *Sticker                       `json:"sticker,omitempty"`
-	Video                         *Video                         `json:"video,omitempty"`
-	VideoNote                     *VideoNote                     `json:"video_note,omitempty"`
-	Voice                         *Voice                         `json:"voice,omitempty"`
-	Caption                       string                         `json:"caption,omitempty"`
-	CaptionEntities               []MessageEntity                `json:"caption_entities,omitempty"`
-	HasMediaSpoiler               bool                           `json:"has_media_spoiler,omitempty"`
-	Contact                       *Contact                       `json:"contact,omitempty"`
-	Dice                          *Dice                          `json:"dice,omitempty"`
-	Game                          *Game                          `json:"game,omitempty"`
-	Poll                          *Poll                          `json:"poll,omitempty"`
-	Venue                         *Venue                         `json:"venue,omitempty"`
-	Location                      *Location                      `json:"location,omitempty"`
-	NewChatMembers                []User                         `json:"new_chat_members,omitempty"`
-	LeftChatMember                *User                          `json:"left_chat_member,omitempty"`
-	NewChatTitle                  string                         `json:"new_chat_title,omitempty"`
-	NewChatPhoto                  []PhotoSize                    `json:"new_chat_photo,omitempty"`
-	DeleteChatPhoto               bool                           `json:"delete_chat_photo,omitempty"`
-	GroupChatCreated              bool                           `json:"group_chat_created,omitempty"`
-	SuperGroupChatCreated         bool                           `json:"supergroup_chat_created,omitempty"`
-	ChannelChatCreated            bool                           `json:"channel_chat_created,omitempty"`
-	MessageAutoDeleteTimerChanged *MessageAutoDeleteTimerChanged `json:"message_auto_delete_timer_changed,omitempty"`
-	MigrateToChatID               int64                          `json:"migrate_to_chat_id,omitempty"`
-	MigrateFromChatID             int64                          `json:"migrate_from_chat_id,omitempty"`
-	PinnedMessage                 *Message                       `json:"pinned_message,omitempty"`
-	Invoice                       *Invoice                       `json:"invoice,omitempty"`
-	SuccessfulPayment             *SuccessfulPayment             `json:"successful_payment,omitempty"`
-	UserShared                    *UserShared                    `json:"user_shared,omitempty"`
-	ChatShared                    *ChatShared                    `json:"chat_shared,omitempty"`
-	ConnectedWebsite              string                         `json:"connected_website,omitempty"`
-	WriteAccessAllowed            *WriteAccessAllowed            `json:"write_access_allowed,omitempty"`
-	PassportData                  *PassportData                  `json:"passport_data,omitempty"`
-	ProximityAlertTriggered       *ProximityAlertTriggered       `json:"proximity_alert_triggered,omitempty"`
-	ForumTopicCreated             *ForumTopicCreated             `json:"forum_topic_created,omitempty"`
-	ForumTopicClosed              *ForumTopicClosed              `json:"forum_topic_closed,omitempty"`
-	ForumTopicReopened            *ForumTopicReopened            `json:"forum_topic_reopened,omitempty"`
-	GeneralForumTopicHidden       *GeneralForumTopicHidden       `json:"general_forum_topic_hidden,omitempty"`
-	GeneralForumTopicUnhidden     *GeneralForumTopicUnhidden     `json:"general_forum_topic_unhidden,omitempty"`
-	VideoChatScheduled            *VideoChatScheduled            `json:"video_chat_scheduled,omitempty"`
-	VideoChatStarted              *VideoChatStarted              `json:"video_chat_started,omitempty"`
-	VideoChatEnded                *VideoChatEnded                `json:"video_chat_ended,omitempty"`
-	VideoChatParticipantsInvited  *VideoChatParticipantsInvited  `json:"video_chat_participants_invited,omitempty"`
-	WebAppData                    *WebAppData                    `json:"web_app_data,omitempty"`
-	ReplyMarkup                   *InlineKeyboardMarkup          `json:"reply_markup,omitempty"`
-}
-
-type From struct {
-	Id           int64  `json:"id"`
-	IsBot        bool   `json:"is_bot"`
-	FirstName    string `json:"first_name"`
-	Username     string `json:"username"`
-	LanguageCode string `json:"language_code"`
-	IsPremium    bool   `json:"is_premium"`
-}
-
-type Chat struct {
-	Id        int64  `json:"id"`
-	FirstName string `json:"first_name"`
-	Username  string `json:"username"`
-	Type      string `json:"type"`
-}
-
-type InlineQuery struct {
-	ID       string    `json:"id"`
-	From     *User     `json:"from"`
-	Query    string    `json:"query"`
-	Offset   string    `json:"offset"`
-	ChatType string    `json:"chat_type,omitempty"`
-	Location *Location `json:"location,omitempty"`
-}
-
-type ChosenInlineResult struct {
-	ResultID        string    `json:"result_id"`
-	From            *User     `json:"from"`
-	Location        *Location `json:"location,omitempty"`
-	InlineMessageID string    `json:"inline_message_id,omitempty"`
-	Query           string    `json:"query"`
-}
-
-type Location struct {
-	Longitude            float64 `json:"longitude"`
-	Latitude             float64 `json:"latitude"`
-	HorizontalAccuracy   float64 `json:"horizontal_accuracy,omitempty"`
-	LivePeriod           int     `json:"live_period,omitempty"`
-	Heading              int     `json:"heading,omitempty"`
-	ProximityAlertRadius int     `json:"proximity_alert_radius,omitempty"`
-}
-
-type CallbackQuery struct {
-	ID              string   `json:"id"`
-	From            *User    `json:"from"`
-	Message         *Message `json:"message,omitempty"`
-	InlineMessageID string   `json:"inline_message_id,omitempty"`
-	ChatInstance    string   `json:"chat_instance"`
-	Data            string   `json:"data,omitempty"`
-	GameShortName   string   `json:"game_short_name,omitempty"`
-}
-
-type ShippingQuery struct {
-	ID              string           `json:"id"`
-	From            *User            `json:"from"`
-	InvoicePayload  string           `json:"invoice_payload"`
-	ShippingAddress *ShippingAddress `json:"shipping_address"`
-}
-
-type PreCheckoutQuery struct {
-	ID               string     `json:"id"`
-	From             *User      `json:"from"`
-	Currency         string     `json:"currency"`
-	TotalAmount      int        `json:"total_amount"`
-	InvoicePayload   string     `json:"invoice_payload"`
-	ShippingOptionID string     `json:"shipping_option_id,omitempty"`
-	OrderInfo        *OrderInfo `json:"order_info,omitempty"`
-}
-
-type Poll struct {
-	ID                    string          `json:"id"`
-	Question              string          `json:"question"`
-	Options               []PollOption    `json:"options"`
-	TotalVoterCount       int             `json:"total_voter_count"`
-	IsClosed              bool            `json:"is_closed"`
-	IsAnonymous           bool            `json:"is_anonymous"`
-	Type                  string          `json:"type"`
-	AllowsMultipleAnswers bool            `json:"allows_multiple_answers"`
-	CorrectOptionID       int             `json:"correct_option_id,omitempty"`
-	Explanation           string          `json:"explanation,omitempty"`
-	ExplanationEntities   []MessageEntity `json:"explanation_entities,omitempty"`
-	OpenPeriod            int             `json:"open_period,omitempty"`
-	CloseDate             int             `json:"close_date,omitempty"`
-}
-
-type PollAnswer struct {
-	PollID    string `json:"poll_id"`
-	User      User   `json:"user"`
-	OptionIDs []int  `json:"option_ids"`
-}
-
-type ChatMemberUpdated struct {
-	Chat                    Chat            `json:"chat"`
-	From                    User            `json:"from"`
-	Date                    int             `json:"date"`
-	OldChatMember           ChatMember      `json:"old_chat_member"`
-	NewChatMember           ChatMember      `json:"new_chat_member"`
-	InviteLink              *ChatInviteLink `json:"invite_link,omitempty"`
-	ViaChatFolderInviteLink bool            `json:"via_chat_folder_invite_link,omitempty"`
-}
-
-type ChatJoinRequest struct {
-	Chat       Chat            `json:"chat"`
-	From       User            `json:"from"`
-	UserChatId int             `json:"user_chat_id,omitempty"`
-	Date       int             `json:"date"`
-	Bio        string          `json:"bio,omitempty"`
-	InviteLink *ChatInviteLink `json:"invite_link,omitempty"`
-}
-
-type MessageEntity struct {
-	Type          string `json:"type"`
-	Offset        int    `json:"offset"`
-	Length        int    `json:"length"`
-	URL           string `json:"url,omitempty"`
-	User          *User  `json:"user,omitempty"`
-	Language      string `json:"language,omitempty"`
-	CustomEmojiID string `json:"custom_emoji_id,omitempty"`
-}
-
-type Animation struct {
-	FileID       string     `json:"file_id"`
-	FileUniqueID string     `json:"file_unique_id"`
-	Width        int        `json:"width"`
-	Height       int        `json:"height"`
-	Duration     int        `json:"duration"`
-	Thumbnail    *PhotoSize `json:"thumb,omitempty"`
-	FileName     string     `json:"file_name,omitempty"`
-	MimeType     string     `json:"mime_type,omitempty"`
-	FileSize     int        `json:"file_size,omitempty"`
-}
-
-type Audio struct {
-	FileID       string     `json:"file_id"`
-	FileUniqueID string     `json:"file_unique_id"`
-	Duration     int        `json:"duration"`
-	Performer    string     `json:"performer,omitempty"`
-	Title        string     `json:"title,omitempty"`
-	FileName     string     `json:"file_name,omitempty"`
-	MimeType     string     `json:"mime_type,omitempty"`
-	FileSize     int        `json:"file_size,omitempty"`
-	Thumbnail    *PhotoSize `json:"thumb,omitempty"`
-}
-
-type Document struct {
-	FileID       string     `json:"file_id"`
-	FileUniqueID string     `json:"file_unique_id"`
-	Thumbnail    *PhotoSize `json:"thumb,omitempty"`
-	FileName     string     `json:"file_name,omitempty"`
-	MimeType     string     `json:"mime_type,omitempty"`
-	FileSize     int        `json:"file_size,omitempty"`
-}
-
-type PhotoSize struct {
-	FileID       string `json:"file_id"`
-	FileUniqueID string `json:"file_unique_id"`
-	Width        int    `json:"width"`
-	Height       int    `json:"height"`
-	FileSize     int    `json:"file_size,omitempty"`
-}
-
-type Sticker struct {
-	FileID           string        `json:"file_id"`
-	FileUniqueID     string        `json:"file_unique_id"`
-	Type_            string        `json:"type,omitempty"`
-	Width            int           `json:"width"`
-	Height           int           `json:"height"`
-	IsAnimated       bool          `json:"is_animated,omitempty"`
-	IsVideo          bool          `json:"is_video,omitempty"`
-	Thumbnail        *PhotoSize    `json:"thumb,omitempty"`
-	Emoji            string        `json:"emoji,omitempty"`
-	SetName          string        `json:"set_name,omitempty"`
-	PremiumAnimation *File         `json:"premium_animation,omitempty"`
-	MaskPosition     *MaskPosition `json:"mask_position,omitempty"`
-	CustomEmojiID    string        `json:"custom_emoji_id,omitempty"`
-	NeedsRepainting  bool          `json:"needs_repainting,omitempty"`
-	FileSize         int           `json:"file_size,omitempty"`
-}
-
-type Video struct {
-	FileID       string     `json:"file_id"`
-	FileUniqueID string     `json:"file_unique_id"`
-	Width        int        `json:"width"`
-	Height       int        `json:"height"`
-	Duration     int        `json:"duration"`
-	Thumbnail    *PhotoSize `json:"thumb,omitempty"`
-	FileName     string     `json:"file_name,omitempty"`
-	MimeType     string     `json:"mime_type,omitempty"`
-	FileSize     int        `json:"file_size,omitempty"`
-}
-
-type File struct {
-	FileID       string `json:"file_id"`
-	FileUniqueID string `json:"file_unique_id"`
-	FileSize     int    `json:"file_size,omitempty"`
-	FilePath     string `json:"file_path,omitempty"`
-}
-
-type MaskPosition struct {
-	Point  string  `json:"point"`
-	XShift float64 `json:"x_shift"`
-	YShift float64 `json:"y_shift"`
-	Scale  float64 `json:"scale"`
-}
-
-type VideoNote struct {
-	FileID       string     `json:"file_id"`
-	FileUniqueID string     `json:"file_unique_id"`
-	Length       int        `json:"length"`
-	Duration     int        `json:"duration"`
-	Thumbnail    *PhotoSize `json:"thumb,omitempty"`
-	FileSize     int        `json:"file_size,omitempty"`
-}
-
-type Voice struct {
-	FileID       string `json:"file_id"`
-	FileUniqueID string `json:"file_unique_id"`
-	Duration     int    `json:"duration"`
-	MimeType     string `json:"mime_type,omitempty"`
-	FileSize     int    `json:"file_size,omitempty"`
-}
-
-type Contact struct {
-	PhoneNumber string `json:"phone_number"`
-	FirstName   string `json:"first_name"`
-	LastName    string `json:"last_name,omitempty"`
-	UserID      int64  `json:"user_id,omitempty"`
-	VCard       string `json:"vcard,omitempty"`
-}
-
-type ChatInviteLink struct {
-	InviteLink              string `json:"invite_link"`
-	Creator                 User   `json:"creator"`
-	CreatesJoinRequest      bool   `json:"creates_join_request,omitempty"`
-	IsPrimary               bool   `json:"is_primary"`
-	IsRevoked               bool   `json:"is_revoked"`
-	Name                    string `json:"name,omitempty"`
-	ExpireDate              int    `json:"expire_date,omitempty"`
-	MemberLimit             int    `json:"member_limit,omitempty"`
-	PendingJoinRequestCount int    `json:"pending_join_request_count,omitempty"`
-}
-
-type ChatMember struct {
-	Status                string `json:"status"`
-	User                  *User  `json:"user"`
-	IsAnonymous           bool   `json:"is_anonymous,omitempty"`
-	CustomTitle           string `json:"custom_title,omitempty"`
-	CanManageChat         bool   `json:"can_manage_chat,omitempty"`
-	CanDeleteMessages     bool   `json:"can_delete_messages,omitempty"`
-	CanManageVideoChats   bool   `json:"can_manage_video_chats,omitempty"`
-	CanRestrictMembers    bool   `json:"can_restrict_members,omitempty"`
-	CanPromoteMembers     bool   `json:"can_promote_members,omitempty"`
-	CanChangeInfo         bool   `json:"can_change_info,omitempty"`
-	CanInviteUsers        bool   `json:"can_invite_users,omitempty"`
-	CanPostMessages       bool   `json:"can_post_messages,omitempty"`
-	CanEditMessages       bool   `json:"can_edit_messages,omitempty"`
-	CanPinMessages        bool   `json:"can_pin_messages,omitempty"`
-	CanManageTopics       bool   `json:"can_manage_topics,omitempty"`
-	CanSendAudios         bool   `json:"can_send_audios,omitempty"`
-	CanSendDocuments      bool   `json:"can_send_documents,omitempty"`
-	CanSendPhotos         bool   `json:"can_send_photos,omitempty"`
-	CanSendVideos         bool   `json:"can_send_videos,omitempty"`
-	CanSendVideoNotes     bool   `json:"can_send_video_notes,omitempty"`
-	CanSendVoiceNotes     bool   `json:"can_send_voice_notes,omitempty"`
-	UntilDate             int64  `json:"until_date,omitempty"`
-	CanBeEdited           bool   `json:"can_be_edited,omitempty"`
-	IsMember              bool   `json:"is_member"`
-	CanSendMessages       bool   `json:"can_send_messages,omitempty"`
-	CanSendMediaMessages  bool   `json:"can_send_media_messages,omitempty"`
-	CanSendPolls          bool   `json:"can_send_polls,omitempty"`
-	CanSendOtherMessages  bool   `json:"can_send_other_messages,omitempty"`
-	CanAddWebPagePreviews bool   `json:"can_add_web_page_previews,omitempty"`
-}
-
-type Dice struct {
-	Emoji string `json:"emoji"`
-	Value int    `json:"value"`
-}
-
-type Game struct {
-	Title        string          `json:"title"`
-	Description  string          `json:"description"`
-	Photo        []PhotoSize     `json:"photo"`
-	Text         string          `json:"text,omitempty"`
-	TextEntities []MessageEntity `json:"text_entities,omitempty"`
-	Animation    *Animation      `json:"animation,omitempty"`
-}
-
-type Venue struct {
-	Location        Location `json:"location"`
-	Title           string   `json:"title"`
-	Address         string   `json:"address"`
-	FoursquareID    string   `json:"foursquare_id,omitempty"`
-	FoursquareType  string   `json:"foursquare_type,omitempty"`
-	GooglePlaceID   string   `json:"google_place_id,omitempty"`
-	GooglePlaceType string   `json:"google_place_type,omitempty"`
-}
-
-type MessageAutoDeleteTimerChanged struct {
-	MessageAutoDeleteTime int `json:"message_auto_delete_time"`
-}
-
-type Invoice struct {
-	Title          string `json:"title"`
-	Description    string `json:"description"`
-	StartParameter string `json:"start_parameter"`
-	Currency       string `json:"currency"`
-	TotalAmount    int    `json:"total_amount"`
-}
-
-type SuccessfulPayment struct {
-	Currency                string     `json:"currency"`
-	TotalAmount             int        `json:"total_amount"`
-	InvoicePayload          string     `json:"invoice_payload"`
-	ShippingOptionID        string     `json:"shipping_option_id,omitempty"`
-	OrderInfo               *OrderInfo `json:"order_info,omitempty"`
-	TelegramPaymentChargeID string     `json:"telegram_payment_charge_id"`
-	ProviderPaymentChargeID string     `json:"provider_payment_charge_id"`
-}
-
-type OrderInfo struct {
-	Name            string           `json:"name,omitempty"`
-	PhoneNumber     string           `json:"phone_number,omitempty"`
-	Email           string           `json:"email,omitempty"`
-	ShippingAddress *ShippingAddress `json:"shipping_address,omitempty"`
-}
-
-type ShippingAddress struct {
-	CountryCode string `json:"country_code"`
-	State       string `json:"state"`
-	City        string `json:"city"`
-	StreetLine1 string `json:"street_line1"`
-	StreetLine2 string `json:"street_line2"`
-	PostCode    string `json:"post_code"`
-}
-
-type UserShared struct {
-	RequestID int `json:"request_id"`
-	UserID    int `json:"user_id"`
-}
-
-type ChatShared struct {
-	RequestID int `json:"request_id"`
-	ChatID    int `json:"chat_id"`
-}
-
-type WriteAccessAllowed struct {
-	WebAppName string `json:"web_app_name,omitempty"`
-}
-
-type PassportData struct {
-	Data        []EncryptedPassportElement `json:"data"`
-	Credentials EncryptedCredentials       `json:"credentials"`
-}
-
-type EncryptedPassportElement struct {
-	Type_       string         `json:"type,omitempty"`
-	Data        string         `json:"data,omitempty"`
-	PhoneNumber string         `json:"phone_number,omitempty"`
-	Email       string         `json:"email,omitempty"`
-	Files       []PassportFile `json:"files,omitempty"`
-	FrontSide   *PassportFile  `json:"front_side,omitempty"`
-	ReverseSide *PassportFile  `json:"reverse_side,omitempty"`
-	Selfie      *PassportFile  `json:"selfie,omitempty"`
-	Translation []PassportFile `json:"translation,omitempty"`
-	Hash        string         `json:"hash,omitempty"`
-}
-
-type EncryptedCredentials struct {
-	Data   string `json:"data,omitempty"`
-	Hash   string `json:"hash,omitempty"`
-	Secret string `json:"secret,omitempty"`
-}
-
-type PassportFile struct {
-	FileID       string `json:"file_id"`
-	FileUniqueID string `json:"file_unique_id"`
-	FileSize     int    `json:"file_size"`
-	FileDate     int    `json:"file_date"`
-}
-
-type ProximityAlertTriggered struct {
-	Traveler User `json:"traveler"`
-	Watcher  User `json:"watcher"`
-	Distance int  `json:"distance"`
-}
-
-type ForumTopicCreated struct {
-	Name              string `json:"name"`
-	IconColor         int    `json:"icon_color"`
-	IconCustomEmojiID string `json:"icon_cusom_emoji_id,omitempty"`
-}
-
-type ForumTopicClosed struct {
-}
-
-type ForumTopicReopened struct {
-}
-
-type GeneralForumTopicHidden struct {
-}
-
-type GeneralForumTopicUnhidden struct {
-}
-
-type VideoChatScheduled struct {
-	StartDate int `json:"start_date"`
-}
-
-type VideoChatStarted struct{}
-
-type VideoChatEnded struct {
-	Duration int `json:"duration"`
-}
-
-type VideoChatParticipantsInvited struct {
-	Users []User `json:"users,omitempty"`
-}
-
-type WebAppData struct {
-	Data       string `json:"data"`
-	ButtonText string `json:"button_text"`
-}
-
-type InlineKeyboardMarkup struct {
-	InlineKeyboard [][]InlineKeyboardButton `json:"inline_keyboard"`
-}
-
-type InlineKeyboardButton struct {
-	Text                         string                       `json:"text"`
-	URL                          string                       `json:"url,omitempty"`
-	CallbackData                 string                       `json:"callback_data,omitempty"`
-	WebApp                       *WebAppInfo                  `json:"web_app,omitempty"`
-	LoginURL                     *LoginURL                    `json:"login_url,omitempty"`
-	SwitchInlineQuery            string                       `json:"switch_inline_query,omitempty"`
-	SwitchInlineQueryCurrentChat string                       `json:"switch_inline_query_current_chat,omitempty"`
-	SwitchInlineQueryChosenChat  *SwitchInlineQueryChosenChat `json:"switch_inline_query_chosen_chat,omitempty"`
-	CallbackGame                 *CallbackGame                `json:"callback_game,omitempty"`
-	Pay                          bool                         `json:"pay,omitempty"`
-}
-
-type WebAppInfo struct {
-	URL string `json:"url"`
-}
-
-type LoginURL struct {
-	URL                string `json:"url"`
-	ForwardText        string `json:"forward_text,omitempty"`
-	BotUsername        string `json:"bot_username,omitempty"`
-	RequestWriteAccess bool   `json:"request_write_access,omitempty"`
-}
-
-type SwitchInlineQueryChosenChat struct {
-	Query             string `json:"query,omitempty"`
-	AllowUserChats    bool   `json:"allow_user_chats,omitempty"`
-	AllowBotChats     bool   `json:"allow_bot_chats,omitempty"`
-	AllowGroupChats   bool   `json:"allow_group_chats,omitempty"`
-	AllowChannelChats bool   `json:"allow_channel_chats,omitempty"`
-}
-
-type CallbackGame struct {
-}
-
-type PollOption struct {
-	Text       string `json:"text"`
-	VoterCount int    `json:"voter_count"`
-}
@@ -0,0 +1,24 @@
+package api
+
+import (
+	"encoding/json"
+)
+
+type Response struct {
+	Ok          bool                `json:"ok"`
+	Result      json.RawMessage     `json:"result"`
+	ErrorCode   int                 `json:"error_code,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Parameters  *ResponseParameters `json:"parameters,omitempty"`
+}
+
+func (resp *Response) Unwrap() (json.RawMessage, error) {
+	if resp.Ok {
+		return resp.Result, nil
+	}
+	return resp.Result, &Error{
+		Code:        resp.ErrorCode,
+		Description: resp.Description,
+		Parameters:  resp.Parameters,
+	}
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Union types and their decoders are generated from the spec into
+// types_gen.go. This file holds what they share and the methods the spec
+// asks variants to implement by hand.
+
+// anyVariant keys the variant unmarshalUnion decodes when no other
+// variant claims the discriminator value.
+const anyVariant = "*"
+
+// Unknown is a union variant this version of the package does not know,
+// such as one added by a newer Bot API. It implements every union decoded
+// from the API, so a new variant does not fail decoding of the update
+// carrying it. Type is the value of the discriminator field and Raw the
+// variant's JSON, which Unknown marshals back to.
+type Unknown struct {
+	Type string
+	Raw  json.RawMessage
+}
+
+func (u Unknown) MarshalJSON() ([]byte, error) {
+	if len(u.Raw) == 0 {
+		return []byte("null"), nil
+	}
+	return u.Raw, nil
+}
+
+func (u *Unknown) MemberStatus() string { return u.Type }
+
+// MemberUser returns the user field of an unknown chat member, or nil.
+func (u *Unknown) MemberUser() *User {
+	var member struct {
+		User *User `json:"user"`
+	}
+	if err := json.Unmarshal(u.Raw, &member); err != nil {
+		return nil
+	}
+	return member.User
+}
+
+func (m *ChatMemberOwner) MemberStatus() string         { return "creator" }
+func (m *ChatMemberAdministrator) MemberStatus() string { return "administrator" }
+func (m *ChatMemberMember) MemberStatus() string        { return "member" }
+func (m *ChatMemberRestricted) MemberStatus() string    { return "restricted" }
+func (m *ChatMemberLeft) MemberStatus() string          { return "left" }
+func (m *ChatMemberBanned) MemberStatus() string        { return "kicked" }
+
+func (m *ChatMemberOwner) MemberUser() *User         { return &m.User }
+func (m *ChatMemberAdministrator) MemberUser() *User { return &m.User }
+func (m *ChatMemberMember) MemberUser() *User        { return &m.User }
+func (m *ChatMemberRestricted) MemberUser() *User    { return &m.User }
+func (m *ChatMemberLeft) MemberUser() *User          { return &m.User }
+func (m *ChatMemberBanned) MemberUser() *User        { return &m.User }
+
+// unmarshalUnion decodes one variant of a union type.
+// It reads the discriminator field of data and decodes data into
+// the value returned by the matching constructor in variants, or by
+// the one keyed anyVariant if none matches. Non-string discriminators
+// are matched by their JSON text, e.g. "0".
+// Values no constructor claims decode to *Unknown.
+// It returns a nil T for empty or null input.
+func unmarshalUnion[T any](data []byte, field string, variants map[string]func() T) (T, error) {
+	var zero T
+	if len(data) == 0 || string(data) == "null" {
+		return zero, nil
+	}
+
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return zero, err
+	}
+
+	raw, ok := probe[field]
+	if !ok {
+		return zero, fmt.Errorf("missing %s discriminator", field)
+	}
+	kind := string(raw)
+	if err := json.Unmarshal(raw, &kind); err != nil {
+		kind = string(raw)
+	}
+
+	newVariant, ok := variants[kind]
+	if !ok {
+		newVariant, ok = variants[anyVariant]
+	}
+	if !ok {
+		unknown, ok := any(&Unknown{Type: kind, Raw: append(json.RawMessage(nil), data...)}).(T)
+		if !ok {
+			return zero, fmt.Errorf("unknown %s %q", field, kind)
+		}
+		return unknown, nil
+	}
+
+	v := newVariant()
+	if err := json.Unmarshal(data, v); err != nil {
+		return zero, err
+	}
+	return v, nil
+}
+
+// unmarshalUnions decodes a list of union values with decode.
+func unmarshalUnions[T any](raws []json.RawMessage, decode func([]byte) (T, error)) ([]T, error) {
+	if raws == nil {
+		return nil, nil
+	}
+
+	values := make([]T, len(raws))
+	for i, raw := range raws {
+		v, err := decode(raw)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
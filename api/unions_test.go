@@ -0,0 +1,174 @@
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestUnknownVariantDoesNotFailUpdates(t *testing.T) {
+	data := `[{"update_id": 1, "message_reaction_count": {
+		"chat": {"id": 5, "type": "group"}, "message_id": 9, "date": 1,
+		"reactions": [
+			{"type": {"type": "emoji", "emoji": "👍"}, "total_count": 2},
+			{"type": {"type": "paid"}, "total_count": 3}
+		]}}, {"update_id": 2}]`
+
+	var updates []Update
+	if err := json.Unmarshal([]byte(data), &updates); err != nil {
+		t.Fatalf("decoding updates: %v", err)
+	}
+	if len(updates) != 2 {
+		t.Fatalf("got %d updates, want 2", len(updates))
+	}
+
+	reactions := updates[0].MessageReactionCount.Reactions
+	if _, ok := reactions[0].Type.(*ReactionTypeEmoji); !ok {
+		t.Errorf("reactions[0] = %T, want *ReactionTypeEmoji", reactions[0].Type)
+	}
+	unknown, ok := reactions[1].Type.(*Unknown)
+	if !ok || unknown.Type != "paid" {
+		t.Fatalf("reactions[1] = %#v, want an Unknown of type paid", reactions[1].Type)
+	}
+
+	out, err := json.Marshal(reactions[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"type":{"type":"paid"},"total_count":3}`; string(out) != want {
+		t.Errorf("re-encoded reaction = %s, want %s", out, want)
+	}
+}
+
+func TestUnknownChatMember(t *testing.T) {
+	member, err := UnmarshalChatMember([]byte(`{"status": "ghost", "user": {"id": 4}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if member.MemberStatus() != "ghost" || member.MemberUser() == nil || member.MemberUser().Id != 4 {
+		t.Errorf("member = %#v, want status ghost and user 4", member)
+	}
+}
+
+func TestChatMemberVariants(t *testing.T) {
+	tests := []struct {
+		data string
+		want ChatMember
+	}{
+		{
+			`{"status": "creator", "user": {"id": 1}, "is_anonymous": true, "custom_title": "Founder"}`,
+			&ChatMemberOwner{Status: "creator", User: User{Id: 1}, IsAnonymous: true, CustomTitle: "Founder"},
+		},
+		{
+			`{"status": "administrator", "user": {"id": 2}, "can_be_edited": true,
+				"can_delete_messages": true, "can_pin_messages": true, "custom_title": "Mod"}`,
+			&ChatMemberAdministrator{Status: "administrator", User: User{Id: 2}, CanBeEdited: true,
+				CanDeleteMessages: true, CanPinMessages: true, CustomTitle: "Mod"},
+		},
+		{
+			`{"status": "member", "user": {"id": 3}}`,
+			&ChatMemberMember{Status: "member", User: User{Id: 3}},
+		},
+		{
+			`{"status": "restricted", "user": {"id": 4}, "is_member": true,
+				"can_send_messages": true, "until_date": 1700000000}`,
+			&ChatMemberRestricted{Status: "restricted", User: User{Id: 4}, IsMember: true,
+				CanSendMessages: true, UntilDate: 1700000000},
+		},
+		{
+			`{"status": "left", "user": {"id": 5}}`,
+			&ChatMemberLeft{Status: "left", User: User{Id: 5}},
+		},
+		{
+			`{"status": "kicked", "user": {"id": 6}, "until_date": 1800000000}`,
+			&ChatMemberBanned{Status: "kicked", User: User{Id: 6}, UntilDate: 1800000000},
+		},
+	}
+	for _, tt := range tests {
+		member, err := UnmarshalChatMember([]byte(tt.data))
+		if err != nil {
+			t.Fatalf("decoding %s: %v", tt.data, err)
+		}
+		if !reflect.DeepEqual(member, tt.want) {
+			t.Errorf("decoding %s:\n got %#v\nwant %#v", tt.data, member, tt.want)
+		}
+	}
+}
+
+func TestMaybeInaccessibleMessage(t *testing.T) {
+	tests := []struct {
+		data string
+		want interface{}
+	}{
+		{`{"message_id": 3, "date": 0, "chat": {"id": 1}}`, &InaccessibleMessage{}},
+		{`{"message_id": 3, "date": 1700000000, "chat": {"id": 1}, "text": "hi"}`, &Message{}},
+	}
+	for _, tt := range tests {
+		var query CallbackQuery
+		data := `{"id": "q", "chat_instance": "c", "message": ` + tt.data + `}`
+		if err := json.Unmarshal([]byte(data), &query); err != nil {
+			t.Fatalf("decoding %s: %v", tt.data, err)
+		}
+
+		switch msg := query.Message.(type) {
+		case *InaccessibleMessage:
+			if _, ok := tt.want.(*InaccessibleMessage); !ok || msg.MessageID != 3 {
+				t.Errorf("%s decoded to %#v", tt.data, msg)
+			}
+		case *Message:
+			if _, ok := tt.want.(*Message); !ok || msg.Text != "hi" {
+				t.Errorf("%s decoded to %#v", tt.data, msg)
+			}
+		default:
+			t.Errorf("%s decoded to %T", tt.data, msg)
+		}
+	}
+}
+
+func TestChatBoostSource(t *testing.T) {
+	data := `{"update_id": 1, "chat_boost": {"chat": {"id": 1}, "boost": {
+		"boost_id": "b", "add_date": 1, "expiration_date": 2,
+		"source": {"source": "giveaway", "giveaway_message_id": 7, "is_unclaimed": true}}}}`
+
+	var update Update
+	if err := json.Unmarshal([]byte(data), &update); err != nil {
+		t.Fatal(err)
+	}
+	source, ok := update.ChatBoost.Boost.Source.(*ChatBoostSourceGiveaway)
+	if !ok || source.GiveawayMessageID != 7 || !source.IsUnclaimed {
+		t.Fatalf("source = %#v, want a giveaway boost", update.ChatBoost.Boost.Source)
+	}
+
+	out, err := json.Marshal(ChatBoostSourcePremium{User: User{Id: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"source":"premium","user":{"id":2}}`; string(out) != want {
+		t.Errorf("marshaled source = %s, want %s", out, want)
+	}
+}
+
+func TestMessageReplyFields(t *testing.T) {
+	data := `{"message_id": 3, "date": 1, "chat": {"id": 5, "type": "private"},
+		"external_reply": {"origin": {"type": "channel", "date": 1,
+			"chat": {"id": -100, "type": "channel"}, "message_id": 7}, "message_id": 7},
+		"quote": {"text": "quoted", "position": 4, "is_manual": true},
+		"users_shared": {"request_id": 1, "user_ids": [10, 20]}}`
+
+	var msg Message
+	if err := json.Unmarshal([]byte(data), &msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg.ExternalReply == nil {
+		t.Fatal("external_reply was not decoded")
+	}
+	if origin, ok := msg.ExternalReply.Origin.(*MessageOriginChannel); !ok || origin.MessageID != 7 {
+		t.Errorf("external reply origin = %#v, want a channel origin", msg.ExternalReply.Origin)
+	}
+	if msg.Quote == nil || msg.Quote.Text != "quoted" || msg.Quote.Position != 4 || !msg.Quote.IsManual {
+		t.Errorf("quote = %+v", msg.Quote)
+	}
+	if msg.UsersShared == nil || len(msg.UsersShared.UserIDs) != 2 || msg.UsersShared.UserIDs[1] != 20 {
+		t.Errorf("users_shared = %+v", msg.UsersShared)
+	}
+}
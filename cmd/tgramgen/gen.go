@@ -0,0 +1,363 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strconv"
+	"strings"
+)
+
+const header = "// Code generated by tgramgen from %s. DO NOT EDIT.\n\n"
+
+// initialisms are name segments written in all caps in Go identifiers.
+var initialisms = map[string]string{
+	"id":   "ID",
+	"ids":  "IDs",
+	"url":  "URL",
+	"html": "HTML",
+	"ip":   "IP",
+}
+
+// wideIntFields are Integer fields that may not fit in 32 bits.
+var wideIntFields = map[string]bool{
+	"id":                   true,
+	"update_id":            true,
+	"chat_id":              true,
+	"user_id":              true,
+	"from_chat_id":         true,
+	"sender_chat_id":       true,
+	"linked_chat_id":       true,
+	"migrate_to_chat_id":   true,
+	"migrate_from_chat_id": true,
+	"until_date":           true,
+}
+
+type generator struct {
+	spec      *Spec
+	apiPkg    string
+	apiImport string
+}
+
+// goName converts a snake_case or camelCase API name to an exported Go name.
+func goName(name string) string {
+	var words []string
+	for _, part := range strings.Split(name, "_") {
+		start := 0
+		for i := 1; i < len(part); i++ {
+			if part[i] >= 'A' && part[i] <= 'Z' {
+				words = append(words, part[start:i])
+				start = i
+			}
+		}
+		words = append(words, part[start:])
+	}
+
+	var b strings.Builder
+	for _, word := range words {
+		if word == "" {
+			continue
+		}
+		if upper, ok := initialisms[strings.ToLower(word)]; ok {
+			b.WriteString(upper)
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
+
+// fieldName returns the Go name of a field.
+func fieldName(field Field) string {
+	if field.GoName != "" {
+		return field.GoName
+	}
+	return goName(field.Name)
+}
+
+// goType maps the documented types of a field to a Go type.
+// qualifier is prepended to named API types, e.g. "api.".
+func (g *generator) goType(field Field, qualifier string) string {
+	if field.GoType != "" {
+		return field.GoType
+	}
+	if len(field.Types) == 1 {
+		return g.singleType(field.Name, field.Types[0], field.Required, qualifier)
+	}
+
+	switch strings.Join(field.Types, " or ") {
+	case "Integer or String":
+		return qualifier + "ChatID"
+	case "InputFile or String":
+		return "*" + qualifier + "InputFile"
+	default:
+		return "interface{}"
+	}
+}
+
+func (g *generator) singleType(name, apiType string, required bool, qualifier string) string {
+	if elem, ok := strings.CutPrefix(apiType, "Array of "); ok {
+		return "[]" + g.singleType(name, elem, true, qualifier)
+	}
+
+	switch apiType {
+	case "Integer":
+		if wideIntFields[name] {
+			return "int64"
+		}
+		return "int"
+	case "String":
+		return "string"
+	case "Boolean", "True":
+		return "bool"
+	case "Float", "Float number":
+		return "float64"
+	case "InputFile":
+		return "*" + qualifier + "InputFile"
+	}
+
+	if required || g.spec.isUnion(apiType) {
+		return qualifier + apiType
+	}
+	return "*" + qualifier + apiType
+}
+
+func jsonTag(field Field) string {
+	if field.Required {
+		return fmt.Sprintf("`json:\"%s\"`", field.Name)
+	}
+	return fmt.Sprintf("`json:\"%s,omitempty\"`", field.Name)
+}
+
+func writeComment(b *bytes.Buffer, lines []string) {
+	for _, line := range lines {
+		fmt.Fprintf(b, "// %s\n", line)
+	}
+}
+
+func writeStruct(b *bytes.Buffer, name string, fields []Field, typeOf func(Field) string) {
+	fmt.Fprintf(b, "type %s struct {\n", name)
+	for _, field := range fields {
+		if field.Embedded {
+			fmt.Fprintf(b, "\t%s\n", typeOf(field))
+			continue
+		}
+		fmt.Fprintf(b, "\t%s %s %s\n", fieldName(field), typeOf(field), jsonTag(field))
+	}
+	b.WriteString("}\n\n")
+}
+
+// genTypes emits the API object types.
+// Unions become interfaces implemented by pointers to their variants,
+// with an Unmarshal<Union> function dispatching on the discriminator
+// of those that have one. *Unknown implements every decodable union.
+// Variants fill in their discriminator when marshaled, and structs
+// holding unions decode them through a generated UnmarshalJSON.
+func (g *generator) genTypes(specPath string) ([]byte, error) {
+	var b bytes.Buffer
+
+	for _, t := range sortedObjects(g.spec.Types) {
+		writeComment(&b, t.Description)
+		if len(t.Subtypes) > 0 {
+			g.writeUnion(&b, t)
+			continue
+		}
+		writeStruct(&b, t.Name, t.Fields, func(f Field) string { return g.goType(f, "") })
+		g.writeVariantMethods(&b, t)
+		g.writeUnionDecoding(&b, t)
+	}
+
+	return source(specPath, g.apiPkg, b.Bytes(), "encoding/json")
+}
+
+func markerName(union string) string {
+	return strings.ToLower(union[:1]) + union[1:]
+}
+
+func (g *generator) writeUnion(b *bytes.Buffer, t *Object) {
+	fmt.Fprintf(b, "type %s interface {\n\t%s()\n", t.Name, markerName(t.Name))
+	for _, method := range t.GoMethods {
+		fmt.Fprintf(b, "\t%s\n", method)
+	}
+	b.WriteString("}\n\n")
+	if t.Discriminator == "" {
+		return
+	}
+
+	fmt.Fprintf(b, "func (*Unknown) %s() {}\n\n", markerName(t.Name))
+
+	fmt.Fprintf(b, "// Unmarshal%s decodes a %s into its concrete variant\n", t.Name, t.Name)
+	fmt.Fprintf(b, "// according to its %s field.\n", t.Discriminator)
+	if t.Fallback != "" {
+		fmt.Fprintf(b, "// Any value no other variant claims decodes to *%s.\n", t.Fallback)
+	} else {
+		b.WriteString("// Unrecognized variants decode to *Unknown.\n")
+	}
+	fmt.Fprintf(b, "func Unmarshal%s(data []byte) (%s, error) {\n", t.Name, t.Name)
+	fmt.Fprintf(b, "\treturn unmarshalUnion(data, %q, map[string]func() %s{\n", t.Discriminator, t.Name)
+	for _, sub := range t.Subtypes {
+		value := strconv.Quote(g.constOf(sub, t.Discriminator))
+		if sub == t.Fallback {
+			value = "anyVariant"
+		}
+		fmt.Fprintf(b, "\t\t%s: func() %s { return &%s{} },\n", value, t.Name, sub)
+	}
+	b.WriteString("\t})\n}\n\n")
+}
+
+func (g *generator) constOf(variant, field string) string {
+	for _, f := range g.spec.Types[variant].Fields {
+		if f.Name == field {
+			return f.Const
+		}
+	}
+	return ""
+}
+
+func (g *generator) writeVariantMethods(b *bytes.Buffer, t *Object) {
+	for _, union := range g.spec.unionsOf(t.Name) {
+		fmt.Fprintf(b, "func (*%s) %s() {}\n\n", t.Name, markerName(union.Name))
+	}
+
+	for _, f := range t.Fields {
+		if f.Const == "" || g.goType(f, "") != "string" {
+			continue
+		}
+		fmt.Fprintf(b, "func (v %s) MarshalJSON() ([]byte, error) {\n", t.Name)
+		fmt.Fprintf(b, "\ttype alias %s\n\tv.%s = %q\n", t.Name, fieldName(f), f.Const)
+		b.WriteString("\treturn json.Marshal(alias(v))\n}\n\n")
+		return
+	}
+}
+
+func (g *generator) writeUnionDecoding(b *bytes.Buffer, t *Object) {
+	var unionFields []Field
+	for _, f := range t.Fields {
+		if _, _, ok := g.spec.unionField(f); ok {
+			unionFields = append(unionFields, f)
+		}
+	}
+	if len(unionFields) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "func (v *%s) UnmarshalJSON(data []byte) error {\n", t.Name)
+	fmt.Fprintf(b, "\ttype alias %s\n\taux := struct {\n\t\t*alias\n", t.Name)
+	for _, f := range unionFields {
+		_, isArray, _ := g.spec.unionField(f)
+		raw := "json.RawMessage"
+		if isArray {
+			raw = "[]json.RawMessage"
+		}
+		fmt.Fprintf(b, "\t\t%s %s `json:\"%s\"`\n", fieldName(f), raw, f.Name)
+	}
+	b.WriteString("\t}{alias: (*alias)(v)}\n")
+	b.WriteString("\tif err := json.Unmarshal(data, &aux); err != nil {\n\t\treturn err\n\t}\n\n\tvar err error\n")
+	for _, f := range unionFields {
+		union, isArray, _ := g.spec.unionField(f)
+		name := fieldName(f)
+		if isArray {
+			fmt.Fprintf(b, "\tif v.%s, err = unmarshalUnions(aux.%s, Unmarshal%s); err != nil {\n", name, name, union)
+		} else {
+			fmt.Fprintf(b, "\tif v.%s, err = Unmarshal%s(aux.%s); err != nil {\n", name, union, name)
+		}
+		b.WriteString("\t\treturn err\n\t}\n")
+	}
+	b.WriteString("\treturn nil\n}\n\n")
+}
+
+// genParams emits a params struct for every method that takes parameters.
+func (g *generator) genParams(specPath string) ([]byte, error) {
+	var b bytes.Buffer
+
+	for _, m := range sortedObjects(g.spec.Methods) {
+		if len(m.Fields) == 0 {
+			continue
+		}
+		writeStruct(&b, goName(m.Name)+"Params", m.Fields, func(f Field) string { return g.goType(f, "") })
+	}
+
+	return source(specPath, g.apiPkg, b.Bytes(), "encoding/json")
+}
+
+// genMethods emits a TgramBot method for every API method
+// not wrapped by hand.
+func (g *generator) genMethods(specPath string) ([]byte, error) {
+	var b bytes.Buffer
+
+	for _, m := range sortedObjects(g.spec.Methods) {
+		if m.Manual {
+			continue
+		}
+		name := goName(m.Name)
+		writeComment(&b, m.Description)
+
+		args, params := "ctx context.Context", "nil"
+		if len(m.Fields) > 0 {
+			args += fmt.Sprintf(", params %s.%sParams", g.apiPkg, name)
+			params = "params"
+		}
+
+		result := g.returnType(m.Returns)
+		if result == "" {
+			fmt.Fprintf(&b, "func (bot *TgramBot) %s(%s) error {\n", name, args)
+			fmt.Fprintf(&b, "\treturn bot.Call(ctx, %q, %s, nil)\n}\n\n", m.Name, params)
+			continue
+		}
+
+		fmt.Fprintf(&b, "func (bot *TgramBot) %s(%s) (%s, error) {\n", name, args, result)
+		if ptr, ok := strings.CutPrefix(result, "*"); ok {
+			fmt.Fprintf(&b, "\tresult := &%s{}\n", ptr)
+			fmt.Fprintf(&b, "\tif err := bot.Call(ctx, %q, %s, result); err != nil {\n", m.Name, params)
+		} else {
+			fmt.Fprintf(&b, "\tvar result %s\n", result)
+			fmt.Fprintf(&b, "\tif err := bot.Call(ctx, %q, %s, &result); err != nil {\n", m.Name, params)
+		}
+		zero := "result"
+		if strings.HasPrefix(result, "*") || strings.HasPrefix(result, "[]") {
+			zero = "nil"
+		}
+		fmt.Fprintf(&b, "\t\treturn %s, err\n\t}\n\treturn result, nil\n}\n\n", zero)
+	}
+
+	return source(specPath, "bot", b.Bytes(), "context", "encoding/json", g.apiImport)
+}
+
+// returnType maps a method's documented result to a Go type.
+// It returns "" for methods that only return True.
+func (g *generator) returnType(returns []string) string {
+	if len(returns) != 1 {
+		return "json.RawMessage"
+	}
+	if returns[0] == "True" {
+		return ""
+	}
+
+	return g.singleType("", returns[0], false, g.apiPkg+".")
+}
+
+// source assembles a formatted Go file, importing only the packages body uses.
+// specPath, named in the header, is relative to the module root.
+func source(specPath, pkg string, body []byte, imports ...string) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, header, specPath)
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+
+	var used []string
+	for _, path := range imports {
+		name := path[strings.LastIndex(path, "/")+1:]
+		if bytes.Contains(body, []byte(name+".")) {
+			used = append(used, path)
+		}
+	}
+	if len(used) > 0 {
+		b.WriteString("import (\n")
+		for _, path := range used {
+			fmt.Fprintf(&b, "\t%q\n", path)
+		}
+		b.WriteString(")\n\n")
+	}
+
+	b.Write(body)
+	return format.Source(b.Bytes())
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const repoRoot = "../.."
+
+// TestGeneratedFilesUpToDate fails when the checked-in generated files
+// differ from what the spec produces. Run go generate ./api to fix it.
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	specPath := filepath.Join(repoRoot, "api/spec/botapi.json")
+	spec, err := loadSpec(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref, err := specRef(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g := &generator{spec: spec, apiPkg: "api", apiImport: defaultAPIImport}
+	outputs := g.outputs(
+		filepath.Join(repoRoot, "api/types_gen.go"),
+		filepath.Join(repoRoot, "api/params_gen.go"),
+		filepath.Join(repoRoot, "pkg/bot/methods_gen.go"),
+	)
+	for _, out := range outputs {
+		want, err := out.gen(ref)
+		if err != nil {
+			t.Fatalf("generating %s: %v", out.path, err)
+		}
+		got, err := os.ReadFile(out.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s is stale; run go generate ./api", out.path)
+		}
+	}
+}
+
+func TestSpecRefIsRelativeToModuleRoot(t *testing.T) {
+	abs, err := filepath.Abs(filepath.Join(repoRoot, "api/spec/botapi.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{abs, "../../api/spec/botapi.json", "../../api/../api/spec/botapi.json"} {
+		ref, err := specRef(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ref != "api/spec/botapi.json" {
+			t.Errorf("specRef(%q) = %q, want api/spec/botapi.json", path, ref)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// Command tgramgen generates Bot API types, method params and TgramBot
+// methods from a machine-readable spec of the Telegram Bot API.
+//
+// Usage:
+//
+//	tgramgen -spec api/spec/botapi.json -types api/types_gen.go \
+//		-params api/params_gen.go -methods pkg/bot/methods_gen.go
+//
+// The spec lists types and methods keyed by their documented name.
+// Methods marked manual keep their hand-written TgramBot wrapper.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+)
+
+const defaultAPIImport = "github.com/saltyFamiliar/tgramAPIBotLib/api"
+
+// output is a file generated from the spec.
+type output struct {
+	path string
+	gen  func(specRef string) ([]byte, error)
+}
+
+// outputs returns the files g generates, written to the given paths.
+func (g *generator) outputs(typesPath, paramsPath, methodsPath string) []output {
+	return []output{
+		{typesPath, g.genTypes},
+		{paramsPath, g.genParams},
+		{methodsPath, g.genMethods},
+	}
+}
+
+func main() {
+	specPath := flag.String("spec", "api/spec/botapi.json", "path to the Bot API spec")
+	typesOut := flag.String("types", "api/types_gen.go", "output file for API types")
+	paramsOut := flag.String("params", "api/params_gen.go", "output file for method params")
+	methodsOut := flag.String("methods", "pkg/bot/methods_gen.go", "output file for TgramBot methods")
+	apiImport := flag.String("api-import", defaultAPIImport, "import path of the api package")
+	flag.Parse()
+
+	spec, err := loadSpec(*specPath)
+	if err != nil {
+		log.Fatalf("unable to load spec: %v", err)
+	}
+	ref, err := specRef(*specPath)
+	if err != nil {
+		log.Fatalf("unable to resolve spec path: %v", err)
+	}
+
+	g := &generator{spec: spec, apiPkg: "api", apiImport: *apiImport}
+	for _, out := range g.outputs(*typesOut, *paramsOut, *methodsOut) {
+		src, err := out.gen(ref)
+		if err != nil {
+			log.Fatalf("unable to generate %s: %v", out.path, err)
+		}
+		if err := os.WriteFile(out.path, src, 0644); err != nil {
+			log.Fatalf("unable to write %s: %v", out.path, err)
+		}
+	}
+}
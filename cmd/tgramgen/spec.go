@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Spec is a machine-readable description of the Bot API.
+// Types and methods are keyed by their name in the official documentation.
+// Description states which part of the API the spec covers.
+type Spec struct {
+	Version     string             `json:"version"`
+	Description []string           `json:"description,omitempty"`
+	Types       map[string]*Object `json:"types"`
+	Methods     map[string]*Object `json:"methods"`
+}
+
+// Object is a type or method of the Bot API.
+// Subtypes lists the variants of a union type and Discriminator names
+// the field that tells them apart. Fallback names the variant decoded
+// for any discriminator value no other variant claims; without one, such
+// values decode to api.Unknown. Unions without a Discriminator, such as
+// those the bot only sends, get no decoder.
+// GoMethods lists methods, beyond the marker, that a union's interface
+// requires; its variants implement them by hand.
+// Returns lists the possible result types of a method. Manual marks
+// methods whose TgramBot wrapper is written by hand in package bot,
+// so only their params are generated.
+type Object struct {
+	Name          string   `json:"name"`
+	Description   []string `json:"description"`
+	Fields        []Field  `json:"fields"`
+	Subtypes      []string `json:"subtypes,omitempty"`
+	Discriminator string   `json:"discriminator,omitempty"`
+	Fallback      string   `json:"fallback,omitempty"`
+	GoMethods     []string `json:"go_methods,omitempty"`
+	Returns       []string `json:"returns,omitempty"`
+	Manual        bool     `json:"manual,omitempty"`
+}
+
+// Field is a field of a type or a parameter of a method.
+// Types holds every type the documentation allows, e.g. ["Integer", "String"].
+// Const is the fixed value of a union variant's discriminator field.
+// GoName and GoType override the Go identifier and type derived from
+// the documentation, keeping names the package exported before it was
+// generated. Embedded fields are written as an embedded struct of GoType,
+// or of their only type.
+type Field struct {
+	Name        string   `json:"name"`
+	Types       []string `json:"types"`
+	Required    bool     `json:"required"`
+	Description string   `json:"description,omitempty"`
+	Const       string   `json:"const,omitempty"`
+	GoName      string   `json:"go_name,omitempty"`
+	GoType      string   `json:"go_type,omitempty"`
+	Embedded    bool     `json:"embedded,omitempty"`
+}
+
+func loadSpec(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &Spec{}
+	if err := json.Unmarshal(data, spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// specRef returns path relative to the root of the module holding it,
+// so generated headers do not depend on where tgramgen runs.
+// Outside a module it returns the file's base name.
+func specRef(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	for dir := filepath.Dir(abs); ; {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			rel, err := filepath.Rel(dir, abs)
+			return filepath.ToSlash(rel), err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return filepath.Base(abs), nil
+		}
+		dir = parent
+	}
+}
+
+func sortedObjects(objects map[string]*Object) []*Object {
+	names := make([]string, 0, len(objects))
+	for name := range objects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	sorted := make([]*Object, len(names))
+	for i, name := range names {
+		sorted[i] = objects[name]
+	}
+	return sorted
+}
+
+func (spec *Spec) isUnion(name string) bool {
+	t, ok := spec.Types[name]
+	return ok && len(t.Subtypes) > 0
+}
+
+// isDecodable reports whether name is a union with a generated decoder.
+func (spec *Spec) isDecodable(name string) bool {
+	return spec.isUnion(name) && spec.Types[name].Discriminator != ""
+}
+
+// unionsOf returns the unions that list name as a subtype.
+func (spec *Spec) unionsOf(name string) []*Object {
+	var unions []*Object
+	for _, t := range sortedObjects(spec.Types) {
+		for _, sub := range t.Subtypes {
+			if sub == name {
+				unions = append(unions, t)
+			}
+		}
+	}
+	return unions
+}
+
+// unionField returns the decodable union a field holds, directly or as
+// an array, and whether it is an array.
+func (spec *Spec) unionField(field Field) (string, bool, bool) {
+	if len(field.Types) != 1 || field.Embedded {
+		return "", false, false
+	}
+	name, isArray := strings.CutPrefix(field.Types[0], "Array of ")
+	return name, isArray, spec.isDecodable(name)
+}
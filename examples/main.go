@@ -1,22 +1,51 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"github.com/saltyFamiliar/tgramAPIBotLib/api"
 	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bot"
 	"log"
+	"log/slog"
+	"strings"
+	"time"
 )
 
 func echo(msg string) (string, error) {
 	return msg, nil
 }
 
+// remind handles "/remind in 10m stretch your legs" by scheduling
+// the text to be sent back to the chat after the delay.
+func remind(ctx context.Context, scheduler *bot.Scheduler, words ...string) (string, error) {
+	if len(words) > 0 && words[0] == "in" {
+		words = words[1:]
+	}
+	if len(words) < 2 {
+		return "", fmt.Errorf("usage: /remind in <duration> <text>")
+	}
+	delay, err := time.ParseDuration(words[0])
+	if err != nil || delay <= 0 {
+		return "", fmt.Errorf("invalid duration %q, try 10m or 1h30m", words[0])
+	}
+	msg, ok := bot.MessageFromContext(ctx)
+	if !ok {
+		return "", fmt.Errorf("no chat to remind")
+	}
+
+	text := strings.Join(words[1:], " ")
+	if _, err := scheduler.After(delay, bot.ScheduledJob{ChatID: msg.Chat.Id, Text: "Reminder: " + text}); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("I'll remind you in %s.", delay), nil
+}
+
 func main() {
 	apiKey, err := api.GetAPIKey("token.txt")
 	if err != nil {
 		log.Fatalln(err)
 	}
-	tGramBot := bot.NewTgramBot(apiKey)
+	tGramBot := bot.NewTgramBot(apiKey, bot.WithCommandMenu(), bot.WithLogger(slog.Default()))
 
 	echoRoutine := bot.NewRoutine(bot.Action{
 		Raw: echo,
@@ -24,10 +53,39 @@ func main() {
 			return echo(i[0].(string))
 		},
 	})
+	echoRoutine.Params = []string{"msg"}
+	echoRoutine.Description = "Repeat a word back"
+	echoRoutine.Usage = "/echo hello"
 
 	if err := tGramBot.RegisterRoutine("echo", echoRoutine); err != nil {
 		fmt.Println(err)
 	}
+	scheduler, err := tGramBot.NewScheduler("schedules.json")
+	if err != nil {
+		log.Fatalln(err)
+	}
+	remindRoutine := bot.NewRoutine(bot.Action{
+		Raw: func(ctx context.Context, words ...string) (string, error) {
+			return remind(ctx, scheduler, words...)
+		},
+		Wrapper: func(i ...interface{}) (string, error) {
+			words := make([]string, 0, len(i)-1)
+			for _, word := range i[1:] {
+				words = append(words, word.(string))
+			}
+			return remind(i[0].(context.Context), scheduler, words...)
+		},
+	})
+	remindRoutine.Params = []string{"in", "duration", "text"}
+	remindRoutine.Description = "Send yourself a reminder later"
+	remindRoutine.Usage = "/remind in 10m stretch your legs"
+
+	if err := tGramBot.RegisterRoutine("remind", remindRoutine); err != nil {
+		fmt.Println(err)
+	}
+	if err := tGramBot.RegisterHelp("help"); err != nil {
+		fmt.Println(err)
+	}
 
 	tGramBot.Run()
 }
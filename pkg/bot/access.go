@@ -0,0 +1,233 @@
+package bot
+
+import (
+	"context"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultDenialMessage is sent when a routine's AccessPolicy rejects
+// an invocation and the policy has no DenialMessage of its own.
+const DefaultDenialMessage = "You are not allowed to use this routine"
+
+// defaultAdminCacheTTL is how long chat admin lookups are cached.
+const defaultAdminCacheTTL = 5 * time.Minute
+
+// AccessPredicate is a custom access check run against the invoking message.
+// It returns false to deny the invocation.
+type AccessPredicate func(ctx context.Context, msg *api.Message) bool
+
+// AccessPolicy restricts who may invoke a Routine.
+// Every configured condition must hold for an invocation to be allowed:
+//   - if UserIDs or Usernames are set, the sender must be listed in either;
+//   - if ChatTypes are set, the chat's type must be one of them,
+//     e.g. "private", "group", "supergroup" or "channel";
+//   - if AdminOnly is set, the sender must be the chat's owner or an
+//     administrator, which is never the case in private chats;
+//   - every Predicate must return true.
+//
+// DenialMessage replaces DefaultDenialMessage; set it to "-" to deny silently.
+type AccessPolicy struct {
+	UserIDs       []int64
+	Usernames     []string
+	ChatTypes     []string
+	AdminOnly     bool
+	Predicates    []AccessPredicate
+	DenialMessage string
+}
+
+// AccessDenial describes an invocation rejected by an AccessPolicy.
+type AccessDenial struct {
+	Hook     string
+	UserID   int64
+	Username string
+	ChatID   int64
+	ChatType string
+	Reason   string
+	Time     time.Time
+}
+
+// denialMessage returns the message sent to denied users, or "" for none.
+func (p *AccessPolicy) denialMessage() string {
+	switch p.DenialMessage {
+	case "":
+		return DefaultDenialMessage
+	case "-":
+		return ""
+	default:
+		return p.DenialMessage
+	}
+}
+
+// check evaluates the policy against msg.
+// It returns the reason the invocation was denied, or "" if it is allowed.
+func (p *AccessPolicy) check(ctx context.Context, bot *TgramBot, msg *api.Message) string {
+	if len(p.UserIDs) > 0 || len(p.Usernames) > 0 {
+		if msg.From == nil || !p.listed(msg.From) {
+			return "user not allowed"
+		}
+	}
+
+	if len(p.ChatTypes) > 0 && !contains(p.ChatTypes, msg.Chat.Type) {
+		return "chat type " + msg.Chat.Type + " not allowed"
+	}
+
+	if p.AdminOnly {
+		if msg.From == nil || msg.Chat.Type == "private" {
+			return "admin only"
+		}
+		isAdmin, err := bot.admins.isAdmin(ctx, bot, msg.Chat.Id, msg.From.Id)
+		if err != nil {
+			return "unable to check admin status: " + err.Error()
+		}
+		if !isAdmin {
+			return "admin only"
+		}
+	}
+
+	for _, predicate := range p.Predicates {
+		if !predicate(ctx, msg) {
+			return "rejected by predicate"
+		}
+	}
+
+	return ""
+}
+
+func (p *AccessPolicy) listed(user *api.User) bool {
+	for _, id := range p.UserIDs {
+		if id == user.Id {
+			return true
+		}
+	}
+	for _, name := range p.Usernames {
+		if user.Username != "" && strings.EqualFold(strings.TrimPrefix(name, "@"), user.Username) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// authorize checks the invocation of routine by msg against its AccessPolicy
+// and those of the command groups above it, outermost first.
+// Denied invocations are reported to the audit function and, unless the
+// policy is silent, answered with its denial message.
+// It returns true if the routine may run.
+func (bot *TgramBot) authorize(ctx context.Context, hook string, routine *Routine, msg *api.Message) bool {
+	var policy *AccessPolicy
+	var reason string
+	for _, r := range routine.path() {
+		if r.Access == nil {
+			continue
+		}
+		if reason = r.Access.check(ctx, bot, msg); reason != "" {
+			policy = r.Access
+			break
+		}
+	}
+	if policy == nil {
+		return true
+	}
+
+	denial := AccessDenial{
+		Hook:     hook,
+		ChatID:   msg.Chat.Id,
+		ChatType: msg.Chat.Type,
+		Reason:   reason,
+		Time:     time.Now(),
+	}
+	if msg.From != nil {
+		denial.UserID = msg.From.Id
+		denial.Username = msg.From.Username
+	}
+	bot.audit(denial)
+
+	if text := policy.denialMessage(); text != "" {
+		if err := bot.SendMsg(ctx, text, msg.Chat.Id); err != nil {
+			bot.logger.Error("unable to send access denial", "chat_id", msg.Chat.Id, "error", err)
+		}
+	}
+	return false
+}
+
+func (bot *TgramBot) logAccessDenial(denial AccessDenial) {
+	bot.logger.Warn("access denied", "routine", denial.Hook, "user_id", denial.UserID,
+		"username", denial.Username, "chat_id", denial.ChatID, "reason", denial.Reason)
+}
+
+type adminKey struct {
+	chatID int64
+	userID int64
+}
+
+type adminEntry struct {
+	isAdmin bool
+	expires time.Time
+}
+
+// adminCache caches whether users are administrators of chats,
+// saving a getChatMember call per AdminOnly invocation.
+// Expired entries are pruned on insert, at most once per ttl.
+type adminCache struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	entries   map[adminKey]adminEntry
+	nextPrune time.Time
+}
+
+func newAdminCache(ttl time.Duration) *adminCache {
+	return &adminCache{ttl: ttl, entries: map[adminKey]adminEntry{}}
+}
+
+func (c *adminCache) isAdmin(ctx context.Context, bot *TgramBot, chatID, userID int64) (bool, error) {
+	key := adminKey{chatID: chatID, userID: userID}
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.isAdmin, nil
+	}
+
+	member, err := bot.GetChatMember(ctx, chatID, userID)
+	if err != nil {
+		return false, err
+	}
+	switch member.(type) {
+	case *api.ChatMemberOwner, *api.ChatMemberAdministrator:
+		entry.isAdmin = true
+	default:
+		entry.isAdmin = false
+	}
+	now := time.Now()
+	entry.expires = now.Add(c.ttl)
+
+	c.mu.Lock()
+	if !now.Before(c.nextPrune) {
+		c.prune(now)
+		c.nextPrune = now.Add(c.ttl)
+	}
+	c.entries[key] = entry
+	c.mu.Unlock()
+	return entry.isAdmin, nil
+}
+
+// prune drops entries that expired by now. It must be called with c.mu held.
+func (c *adminCache) prune(now time.Time) {
+	for key, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+}
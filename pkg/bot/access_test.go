@@ -0,0 +1,191 @@
+package bot_test
+
+import (
+	"context"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bot"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bottest"
+	"testing"
+	"time"
+)
+
+// chatMessage returns a message update from user in a chat of the given type.
+func chatMessage(chatID int64, chatType string, user api.User, text string) api.Update {
+	return api.Update{Message: &api.Message{
+		From: &user,
+		Date: int(time.Now().Unix()),
+		Chat: &api.Chat{Id: chatID, Type: chatType},
+		Text: text,
+	}}
+}
+
+// guardedBot returns a bot whose echo routine is restricted by policy,
+// and a channel receiving the denials it audits.
+func guardedBot(s *bottest.Server, policy *bot.AccessPolicy, opts ...bot.Option) (*bot.TgramBot, <-chan bot.AccessDenial) {
+	denials := make(chan bot.AccessDenial, 10)
+	opts = append(opts,
+		bot.WithAck(bot.AckConfig{Mode: bot.AckNone}),
+		bot.WithAccessAudit(func(denial bot.AccessDenial) { denials <- denial }),
+	)
+	tgBot := s.Bot(opts...)
+	echo := echoRoutine()
+	echo.Access = policy
+	if err := tgBot.RegisterRoutine("echo", echo); err != nil {
+		panic(err)
+	}
+	return tgBot, denials
+}
+
+func TestAccessPolicyConditions(t *testing.T) {
+	alice := api.User{Id: 1, Username: "alice"}
+	bob := api.User{Id: 2, Username: "bob"}
+	allowAlice := func(_ context.Context, msg *api.Message) bool { return msg.From.Id == alice.Id }
+
+	tests := []struct {
+		name     string
+		policy   bot.AccessPolicy
+		chatType string
+		user     api.User
+		allowed  bool
+	}{
+		{"listed user id", bot.AccessPolicy{UserIDs: []int64{1}}, "private", alice, true},
+		{"unlisted user id", bot.AccessPolicy{UserIDs: []int64{1}}, "private", bob, false},
+		{"listed username", bot.AccessPolicy{Usernames: []string{"@Alice"}}, "private", alice, true},
+		{"unlisted username", bot.AccessPolicy{Usernames: []string{"@Alice"}}, "private", bob, false},
+		{"id or username", bot.AccessPolicy{UserIDs: []int64{2}, Usernames: []string{"alice"}}, "private", alice, true},
+		{"allowed chat type", bot.AccessPolicy{ChatTypes: []string{"group", "supergroup"}}, "supergroup", alice, true},
+		{"other chat type", bot.AccessPolicy{ChatTypes: []string{"group", "supergroup"}}, "private", alice, false},
+		{"predicate allows", bot.AccessPolicy{Predicates: []bot.AccessPredicate{allowAlice}}, "private", alice, true},
+		{"predicate denies", bot.AccessPolicy{Predicates: []bot.AccessPredicate{allowAlice}}, "private", bob, false},
+		{"every condition must hold", bot.AccessPolicy{UserIDs: []int64{1}, ChatTypes: []string{"group"}}, "private", alice, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := bottest.NewServer()
+			defer s.Close()
+			tgBot, denials := guardedBot(s, &tt.policy)
+
+			s.PushUpdate(chatMessage(-100, tt.chatType, tt.user, "/echo hi"))
+			go tgBot.Run()
+
+			if len(s.WaitForCall("sendMessage", 1, 5*time.Second)) == 0 {
+				t.Fatal("the bot did not reply")
+			}
+			if tt.allowed {
+				s.AssertSent(t, -100, "hi")
+				if len(denials) != 0 {
+					t.Errorf("allowed invocation was audited: %+v", <-denials)
+				}
+				return
+			}
+			s.AssertSent(t, -100, bot.DefaultDenialMessage)
+			s.AssertNotSent(t, -100, "hi")
+			if len(denials) != 1 {
+				t.Fatalf("audited %d denials, want 1", len(denials))
+			}
+			if denial := <-denials; denial.Hook != "echo" || denial.UserID != tt.user.Id || denial.ChatType != tt.chatType {
+				t.Errorf("denial = %+v", denial)
+			}
+		})
+	}
+}
+
+func TestAccessDenialReply(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{"default", "", bot.DefaultDenialMessage},
+		{"custom", "Admins only, sorry", "Admins only, sorry"},
+		{"silent", "-", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := bottest.NewServer()
+			defer s.Close()
+			tgBot, denials := guardedBot(s, &bot.AccessPolicy{UserIDs: []int64{1}, DenialMessage: tt.message})
+
+			s.PushUpdate(chatMessage(-100, "group", api.User{Id: 2}, "/echo hi"))
+			go tgBot.Run()
+
+			select {
+			case <-denials:
+			case <-time.After(5 * time.Second):
+				t.Fatal("the invocation was not denied")
+			}
+			if tt.want == "" {
+				time.Sleep(50 * time.Millisecond)
+				s.AssertNotCalled(t, "sendMessage")
+				return
+			}
+			s.WaitForCall("sendMessage", 1, 5*time.Second)
+			s.AssertSent(t, -100, tt.want)
+		})
+	}
+}
+
+func TestAdminOnly(t *testing.T) {
+	// Run polls every 4 seconds, so the cache has to outlive one poll
+	// and expire before the second.
+	const ttl = 6 * time.Second
+	s := bottest.NewServer()
+	defer s.Close()
+	s.Handle("getChatMember", func(call bottest.Call) (interface{}, error) {
+		status := "member"
+		if call.Int("user_id") == 1 {
+			status = "administrator"
+		}
+		return map[string]interface{}{"status": status, "user": api.User{Id: call.Int("user_id")}}, nil
+	})
+	tgBot, _ := guardedBot(s, &bot.AccessPolicy{AdminOnly: true}, bot.WithAdminCacheTTL(ttl))
+
+	admin, member := api.User{Id: 1}, api.User{Id: 2}
+	lookups := func(want int) {
+		t.Helper()
+		if got := len(s.Calls("getChatMember")); got != want {
+			t.Errorf("getChatMember called %d times, want %d", got, want)
+		}
+	}
+	waitForReplies := func(n int) {
+		t.Helper()
+		if len(s.WaitForCall("sendMessage", n, 10*time.Second)) < n {
+			t.Fatalf("the bot sent fewer than %d messages", n)
+		}
+	}
+
+	s.PushUpdate(chatMessage(-100, "supergroup", admin, "/echo first"))
+	go tgBot.Run()
+	waitForReplies(1)
+	lookups(1)
+	s.AssertSent(t, -100, "first")
+	if call := s.Calls("getChatMember")[0]; call.Int("chat_id") != -100 || call.Int("user_id") != 1 {
+		t.Errorf("getChatMember params = %v, want chat -100 and user 1", call.Params)
+	}
+
+	s.PushUpdate(chatMessage(-100, "supergroup", admin, "/echo cached"))
+	s.PushUpdate(chatMessage(-100, "supergroup", member, "/echo denied"))
+	s.PushUpdate(chatMessage(-100, "private", admin, "/echo private"))
+	waitForReplies(4)
+	time.Sleep(50 * time.Millisecond)
+	lookups(2)
+	s.AssertSent(t, -100, "cached")
+	s.AssertNotSent(t, -100, "denied")
+	s.AssertNotSent(t, -100, "private")
+	denials := 0
+	for _, msg := range s.SentMessages(-100) {
+		if msg.Text == bot.DefaultDenialMessage {
+			denials++
+		}
+	}
+	if denials != 2 {
+		t.Errorf("sent %d denials, want one each to the member and the private chat", denials)
+	}
+
+	s.PushUpdate(chatMessage(-100, "supergroup", admin, "/echo expired"))
+	waitForReplies(5)
+	lookups(3)
+	s.AssertSent(t, -100, "expired")
+}
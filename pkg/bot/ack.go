@@ -0,0 +1,132 @@
+package bot
+
+import (
+	"context"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+	"strings"
+	"time"
+)
+
+// TextPlaceholder is replaced by the message text in acknowledgements.
+const TextPlaceholder = "{text}"
+
+// DefaultAckTemplate is the acknowledgement Run sends for each message
+// unless WithAck says otherwise.
+const DefaultAckTemplate = "Received request: " + TextPlaceholder
+
+// defaultChatActionInterval resends chat actions just before Telegram
+// hides them, which it does after 5 seconds.
+const defaultChatActionInterval = 4 * time.Second
+
+// AckMode selects how Run acknowledges incoming messages.
+type AckMode int
+
+const (
+	// AckMessage replies with a message rendered from AckConfig.Template.
+	AckMessage AckMode = iota
+	// AckNone sends no acknowledgement.
+	AckNone
+	// AckChatAction shows a chat action such as "typing" for as long as
+	// the routine runs, stopping once its reply is sent.
+	AckChatAction
+)
+
+// AckConfig configures acknowledgements. Template defaults to
+// DefaultAckTemplate; TextPlaceholder in it is replaced by the message
+// text and everything else, % verbs included, is sent as is.
+// Action defaults to api.ChatActionTyping and Interval, how often the
+// chat action is resent, to 4 seconds.
+// A Routine's ChatAction overrides Action.
+// With CommandsOnly set, only messages invoking a routine are acknowledged.
+type AckConfig struct {
+	Mode         AckMode
+	Template     string
+	Action       string
+	Interval     time.Duration
+	CommandsOnly bool
+}
+
+func (c AckConfig) template() string {
+	if c.Template == "" {
+		return DefaultAckTemplate
+	}
+	return c.Template
+}
+
+func (c AckConfig) action(routine *Routine) string {
+	switch {
+	case routine.ChatAction != "":
+		return routine.ChatAction
+	case c.Action != "":
+		return c.Action
+	default:
+		return api.ChatActionTyping
+	}
+}
+
+func (c AckConfig) interval() time.Duration {
+	if c.Interval <= 0 {
+		return defaultChatActionInterval
+	}
+	return c.Interval
+}
+
+// acknowledge sends the acknowledgement message for msg, if the bot's
+// AckConfig asks for one.
+func (bot *TgramBot) acknowledge(ctx context.Context, msg *api.Message) {
+	if bot.ack.Mode != AckMessage {
+		return
+	}
+	if bot.ack.CommandsOnly {
+		if _, _, ok := bot.lookup(strings.Split(msg.Text, " ")[0]); !ok {
+			return
+		}
+	}
+
+	text := strings.ReplaceAll(bot.ack.template(), TextPlaceholder, msg.Text)
+	done := startWork(ctx)
+	go func() {
+		defer done()
+		bot.reply(ctx, text, msg)
+	}()
+}
+
+// showChatAction repeatedly sends the chat action for routine to the
+// chat of msg until the returned stop function is called.
+// It does nothing unless the bot's AckConfig uses AckChatAction.
+func (bot *TgramBot) showChatAction(ctx context.Context, routine *Routine, msg *api.Message) (stop func()) {
+	if bot.ack.Mode != AckChatAction {
+		return func() {}
+	}
+
+	params := api.SendChatActionParams{
+		ChatID:          msg.Chat.Id,
+		MessageThreadID: msg.MessageThreadId,
+		Action:          bot.ack.action(routine),
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(bot.ack.interval())
+		defer ticker.Stop()
+		for {
+			callCtx, callCancel := context.WithTimeout(ctx, 5*time.Second)
+			if err := bot.SendChatAction(callCtx, params); err != nil && ctx.Err() == nil {
+				bot.logger.Error("unable to send chat action", "chat_id", params.ChatID, "error", err)
+			}
+			callCancel()
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
@@ -0,0 +1,122 @@
+package bot_test
+
+import (
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bot"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bottest"
+	"testing"
+	"time"
+)
+
+// slowRoutine returns a routine that replies "done" after delay.
+func slowRoutine(delay time.Duration) *bot.Routine {
+	return bot.NewRoutine(bot.Action{
+		Raw: func() {},
+		Wrapper: func(...interface{}) (string, error) {
+			time.Sleep(delay)
+			return "done", nil
+		},
+	})
+}
+
+func TestAckMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{"default template", "", "Received request: /echo 100%"},
+		{"placeholder", "Working on {text}...", "Working on /echo 100%..."},
+		{"percent verbs are literal", "100% on %s", "100% on %s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := bottest.NewServer()
+			defer s.Close()
+			tgBot := s.Bot(bot.WithAck(bot.AckConfig{Template: tt.template}))
+			if err := tgBot.RegisterRoutine("echo", echoRoutine()); err != nil {
+				t.Fatal(err)
+			}
+
+			s.PushMessage(1, "/echo 100%")
+			go tgBot.Run()
+
+			s.WaitForCall("sendMessage", 2, 5*time.Second)
+			s.AssertSent(t, 1, tt.want)
+			s.AssertSent(t, 1, "100%")
+		})
+	}
+}
+
+func TestAckNone(t *testing.T) {
+	s := bottest.NewServer()
+	defer s.Close()
+	tgBot := s.Bot(bot.WithAck(bot.AckConfig{Mode: bot.AckNone}))
+	if err := tgBot.RegisterRoutine("echo", echoRoutine()); err != nil {
+		t.Fatal(err)
+	}
+
+	s.PushMessage(1, "/echo hi")
+	s.PushMessage(1, "just chatting")
+	go tgBot.Run()
+
+	s.WaitForCall("sendMessage", 1, 5*time.Second)
+	time.Sleep(50 * time.Millisecond)
+	if sent := s.SentMessages(1); len(sent) != 1 || sent[0].Text != "hi" {
+		t.Errorf("sent %+v, want only the routine's reply", sent)
+	}
+	s.AssertNotCalled(t, "sendChatAction")
+}
+
+func TestAckCommandsOnly(t *testing.T) {
+	s := bottest.NewServer()
+	defer s.Close()
+	tgBot := s.Bot(bot.WithAck(bot.AckConfig{Template: "On it: {text}", CommandsOnly: true}))
+	if err := tgBot.RegisterRoutine("echo", echoRoutine()); err != nil {
+		t.Fatal(err)
+	}
+
+	s.PushMessage(1, "just chatting")
+	s.PushMessage(1, "/echo hi")
+	go tgBot.Run()
+
+	s.WaitForCall("sendMessage", 2, 5*time.Second)
+	time.Sleep(50 * time.Millisecond)
+	s.AssertSent(t, 1, "On it: /echo hi")
+	s.AssertSent(t, 1, "hi")
+	s.AssertNotSent(t, 1, "On it: just chatting")
+}
+
+func TestAckChatAction(t *testing.T) {
+	s := bottest.NewServer()
+	defer s.Close()
+	tgBot := s.Bot(bot.WithAck(bot.AckConfig{Mode: bot.AckChatAction, Interval: 20 * time.Millisecond}))
+	slow := slowRoutine(150 * time.Millisecond)
+	slow.ChatAction = api.ChatActionUploadDocument
+	if err := tgBot.RegisterRoutine("report", slow); err != nil {
+		t.Fatal(err)
+	}
+
+	s.PushMessage(1, "/report")
+	go tgBot.Run()
+
+	if len(s.WaitForCall("sendMessage", 1, 5*time.Second)) == 0 {
+		t.Fatal("the routine did not reply")
+	}
+	actions := s.Calls("sendChatAction")
+	if len(actions) < 2 {
+		t.Fatalf("sent %d chat actions while the routine ran, want it resent every interval", len(actions))
+	}
+	for _, call := range actions {
+		if call.Int("chat_id") != 1 || call.Param("action") != api.ChatActionUploadDocument {
+			t.Errorf("chat action params = %v, want the routine's action in chat 1", call.Params)
+		}
+	}
+	s.AssertNotSent(t, 1, "Received request: /report")
+
+	time.Sleep(100 * time.Millisecond)
+	if after := s.Calls("sendChatAction"); len(after) != len(actions) {
+		t.Errorf("chat action was sent %d more times after the reply", len(after)-len(actions))
+	}
+}
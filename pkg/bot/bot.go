@@ -2,10 +2,10 @@ package bot
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/saltyFamiliar/tgramAPIBotLib/api"
-	"log"
+	"log/slog"
 	"net/http"
 	"strings"
 	"time"
@@ -18,54 +18,84 @@ type RoutineRegistry map[string]*Routine
 // TgramBot is the main Telegram bot struct.
 // It contains the current update offset, API key,
 // registry mapping of hook strings to Routines,
-// and an HTTP client for making API requests.
+// the Bot API server settings,
+// an HTTP client for making API requests,
+// handlers for non-message updates,
+// the state used to enforce routine access policies and rate limits,
+// and the runtime status reported by AdminHandler.
 type TgramBot struct {
-	Offset   int
-	key      string
-	Registry RoutineRegistry
-	client   *http.Client
+	Offset    int
+	key       string
+	Registry  RoutineRegistry
+	baseURL   string
+	userAgent string
+	testEnv   bool
+	local     bool
+	client    *http.Client
+	handlers  updateHandlers
+	admins    *adminCache
+	audit     func(AccessDenial)
+	throttle  *Throttle
+
+	publishCommands bool
+	commandScopes   []api.BotCommandScope
+
+	prefixMatching bool
+	unknownReply   func(command, suggestion string) string
+	ack            AckConfig
+	logger         *slog.Logger
+	metrics        *botMetrics
+	tracer         Tracer
+	status         runtimeStatus
+	schedulers     []*Scheduler
 }
 
 // NewTgramBot constructs a new TgramBot instance.
 // It initializes the offset to 0, API key to the provided key,
-// and empty Registry and HTTP client.
-func NewTgramBot(apiKey string) *TgramBot {
-	return &TgramBot{
+// base URL to api.BaseURL, and empty Registry and HTTP client.
+// Any provided Options are applied afterwards in order.
+func NewTgramBot(apiKey string, opts ...Option) *TgramBot {
+	bot := &TgramBot{
 		Offset:   0,
 		key:      apiKey,
 		Registry: RoutineRegistry{},
+		baseURL:  api.BaseURL,
 		client:   &http.Client{},
+		admins:   newAdminCache(defaultAdminCacheTTL),
+
+		unknownReply: defaultUnknownCommandReply,
+		logger:       slog.New(discardHandler{}),
+		tracer:       noopTracer{},
+	}
+	bot.audit = bot.logAccessDenial
+
+	for _, opt := range opts {
+		opt(bot)
 	}
+
+	return bot
 }
 
 // APIRequest makes a request to the Telegram Bot API.
 // It accepts a context.Context and API resource endpoint as arguments.
 // It returns a api.Response struct and error.
 func (bot *TgramBot) APIRequest(ctx context.Context, resource string) (*api.Response, error) {
-	reqUrl := api.MakeEndpointStr(resource, bot.key)
-	req, err := http.NewRequestWithContext(ctx, "GET", reqUrl, nil)
-	if err != nil {
-		return nil, err
-	}
+	method, _, _ := strings.Cut(resource, "?")
+	ctx, span := bot.tracer.Start(ctx, SpanAPICall+" "+method, Attr("tgram.method", method))
+	defer span.End()
 
-	response, err := bot.client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-
-	defer func() {
-		err := response.Body.Close()
-		if err != nil {
-			log.Printf("Error closing response body: %v,", err)
-		}
-	}()
+	resp, err := bot.apiRequest(ctx, resource)
+	span.RecordError(err)
+	return resp, err
+}
 
-	respBody := &api.Response{}
-	if err := json.NewDecoder(response.Body).Decode(respBody); err != nil {
+func (bot *TgramBot) apiRequest(ctx context.Context, resource string) (*api.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", bot.endpoint(resource), nil)
+	if err != nil {
 		return nil, err
 	}
 
-	return respBody, nil
+	return bot.do(req)
 }
 
 // GetMe retrieves basic information about the bot from the Telegram API.
@@ -73,19 +103,9 @@ func (bot *TgramBot) APIRequest(ctx context.Context, resource string) (*api.Resp
 // It accepts a context.Context as an argument.
 // It returns an api.User struct containing info about the bot, and an error.
 func (bot *TgramBot) GetMe(ctx context.Context) (*api.User, error) {
-	resp, err := bot.APIRequest(ctx, "getMe")
-	if err != nil {
-		return nil, err
-	}
-
-	result, err := resp.Unwrap()
-	if err != nil {
-		return nil, err
-	}
-
 	user := &api.User{}
-	if err := json.Unmarshal(result, user); err != nil {
-		return nil, fmt.Errorf("unable to unmarshal response body: %v", err)
+	if err := bot.Call(ctx, "getMe", nil, user); err != nil {
+		return nil, err
 	}
 
 	return user, nil
@@ -95,8 +115,7 @@ func (bot *TgramBot) GetMe(ctx context.Context) (*api.User, error) {
 // It accepts a context.Context, message text, and target chat ID.
 // It returns any error from the API request.
 func (bot *TgramBot) SendMsg(ctx context.Context, msg string, chatID int64) error {
-	req := fmt.Sprintf("sendMessage?chat_id=%d&text=%s", chatID, msg)
-	_, err := bot.APIRequest(ctx, req)
+	_, err := bot.SendMessage(ctx, api.SendMessageParams{ChatID: chatID, Text: msg})
 	return err
 }
 
@@ -105,11 +124,9 @@ func (bot *TgramBot) SendMsg(ctx context.Context, msg string, chatID int64) erro
 // It uses a temporary context.Context with the timeout.
 // It returns any error from the API request.
 func (bot *TgramBot) SendMsgWithTimeout(msg string, chatID int64, timeout time.Duration) error {
-	req := fmt.Sprintf("sendMessage?chat_id=%d&text=%s", chatID, msg)
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
-	_, err := bot.APIRequest(ctx, req)
-	return err
+	return bot.SendMsg(ctx, msg, chatID)
 }
 
 // GetUpdates retrieves new update objects from the Telegram Bot API.
@@ -117,19 +134,10 @@ func (bot *TgramBot) SendMsgWithTimeout(msg string, chatID int64, timeout time.D
 // It accepts a context.Context as an argument.
 // It returns a slice of api.Update structs representing new updates, and an error.
 func (bot *TgramBot) GetUpdates(ctx context.Context) ([]api.Update, error) {
-	resp, err := bot.APIRequest(ctx, fmt.Sprintf("getUpdates?offset=%d", bot.Offset))
-	if err != nil {
-		return nil, err
-	}
-
-	result, err := resp.Unwrap()
-	if err != nil {
-		return nil, err
-	}
-
 	var updates []api.Update
-	if err := json.Unmarshal(result, &updates); err != nil {
-		return nil, fmt.Errorf("unable to marshal result into []Update: %w", err)
+	params := api.GetUpdatesParams{Offset: bot.Offset, AllowedUpdates: bot.allowedUpdates()}
+	if err := bot.Call(ctx, "getUpdates", params, &updates); err != nil {
+		return nil, err
 	}
 
 	return updates, nil
@@ -138,62 +146,97 @@ func (bot *TgramBot) GetUpdates(ctx context.Context) ([]api.Update, error) {
 // RegisterRoutine registers a Routine struct to handle a specific hook.
 // It accepts the hook name as a string, and pointer to the Routine struct.
 // The TgramBot's Registry map is updated to map the hook to the routine.
-// It returns an error if the hook name is already taken.
+// It returns an error if the hook name or one of the routine's Aliases is
+// already taken, ignoring case and a leading slash.
 func (bot *TgramBot) RegisterRoutine(hook string, routine *Routine) error {
-	if _, hookTaken := bot.Registry[hook]; !hookTaken {
-		bot.Registry[hook] = routine
-		return nil
+	for _, name := range routineNames(hook, routine) {
+		if _, _, taken := find(bot.Registry, name, true, false); taken {
+			return fmt.Errorf("couldn't register routine: name taken")
+		}
 	}
-	return fmt.Errorf("couldn't register routine: name taken")
+	bot.Registry[hook] = routine
+	return nil
 }
 
 // ParseMessage parses a chat message to extract the routine hook and arguments.
 // It splits the message into words. The first word is assumed to be the routine hook.
-// It returns the corresponding Routine struct from the bot's Registry map.
+// It returns the corresponding Routine struct from the bot's Registry map,
+// ignoring case and a leading slash or trailing @botname on the hook.
+// Routines also match their Aliases, and unique prefixes of their names
+// if WithPrefixMatching was given.
+// If the routine has Subcommands, the following words select a subcommand,
+// walking down the command tree for as long as they match.
 // Any subsequent words are returned as a string slice of arguments.
-// It returns an error if no routine is registered for the given hook.
+// It returns an *UnknownCommandError if no routine is registered for the given hook,
+// or if a command group is given no valid subcommand.
 func (bot *TgramBot) ParseMessage(msg string) (*Routine, []string, error) {
-	words := strings.Split(msg, " ")
-	routine, ok := bot.Registry[words[0]]
-	if !ok {
-		return nil, nil, fmt.Errorf("routine not found")
-	}
-
-	var args []string
-	if len(words) > 1 {
-		args = words[1:]
+	routine, _, args, err := bot.resolve(msg)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	return routine, args, nil
 }
 
 // Run starts the main loop for fetching updates and handling requests.
+// If WithCommandMenu was given, it first publishes the bot's commands.
 // It creates two channels for updates and jobs.
-// A goroutine fetches updates from the API every few seconds
+// A goroutine validates the bot's token with getMe until it succeeds,
+// fetches updates from the API every few seconds
 // and sends them to the updates channel.
 // Another goroutine listens to the updates channel,
-// processes each update into a job,
 // updates the bot's offset,
-// and sends the job to the jobs channel.
+// passes updates with a registered handler to that handler,
+// passes messages that do not invoke a routine to the first matching
+// filter handler,
+// drops messages refused by the bot's or the routine's Throttle,
+// processes each message update into a job,
+// sends the job to the jobs channel,
+// and acknowledges the message as set by WithAck.
 // For each job, a goroutine parses the message,
-// executes the matching routine,
+// replies to unknown commands as set by WithUnknownCommandReply,
+// checks the AccessPolicy of the routine and its command groups,
+// executes the matching routine while showing a chat action if configured,
 // and sends the routine's response back to the user.
+// Schedulers created with NewScheduler run alongside.
+// If WithTracer was given, each update is traced with a span that is the
+// parent of the spans of its handler or routine and their API calls,
+// and ends once they finish.
 // This loop continues indefinitely to continuously
 // process updates and handle requests.
 func (bot *TgramBot) Run() {
+	if bot.publishCommands {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		if err := bot.PublishCommands(ctx); err != nil {
+			bot.logger.Error("unable to publish commands", "error", err)
+		}
+		cancel()
+	}
+
 	updatesCh := make(chan []api.Update, 10)
-	jobCh := make(chan *api.Message, 10)
+	jobCh := make(chan job, 10)
+	bot.status.start(routineHooks(bot.Registry, ""))
+	for _, s := range bot.schedulers {
+		go s.Run(context.Background())
+	}
 
 	// update producer
 	go func() {
 		for {
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			if !bot.status.ready() {
+				bot.validateToken(ctx)
+			}
+			start := time.Now()
 			updates, err := bot.GetUpdates(ctx)
+			bot.metrics.poll(time.Since(start))
+			bot.status.polled(err)
 			if err != nil {
-				log.Printf("Error getting updates: %v", err)
+				bot.logger.Error("unable to get updates", "error", err)
 			}
 			cancel()
 			updatesCh <- updates
+			bot.queued("updates", len(updatesCh))
 			time.Sleep(4 * time.Second)
 		}
 	}()
@@ -201,45 +244,123 @@ func (bot *TgramBot) Run() {
 	// consumes updates, produces jobs
 	go func() {
 		for updates := range updatesCh {
+			bot.queued("updates", len(updatesCh))
 			for _, update := range updates {
 				bot.Offset = int(update.UpdateId) + 1
-				if update.Message == nil {
-					continue
-				}
-
-				jobCh <- update.Message
-				go func(msg *api.Message) {
-					ackMsg := fmt.Sprintf("Received request: %s", msg.Text)
-					if err := bot.SendMsgWithTimeout(ackMsg, msg.Chat.Id, 5*time.Second); err != nil {
-						fmt.Println(err)
-					}
-				}(update.Message)
+				bot.logger.Debug("received update", "update_id", update.UpdateId)
+				bot.metrics.update(update)
+				ctx, span := bot.tracer.Start(context.Background(), SpanUpdate,
+					Attr("tgram.update_id", update.UpdateId), Attr("tgram.update_type", updateType(update)))
+				ctx, work := withUpdateWork(ctx)
+				bot.dispatch(ctx, update, jobCh)
+				go func() {
+					work.Wait()
+					span.End()
+				}()
+				bot.status.processed(bot.Offset)
 			}
 		}
 	}()
 
 	// consumes jobs, sends output to user
-	for job := range jobCh {
-		go func(reqMsg *api.Message) {
-			routine, args, err := bot.ParseMessage(reqMsg.Text)
-			if err != nil {
-				if msgErr := bot.SendMsgWithTimeout(err.Error(), reqMsg.Chat.Id, 5*time.Second); msgErr != nil {
-					fmt.Println(msgErr)
-				}
-				return
-			}
+	for j := range jobCh {
+		bot.queued("jobs", len(jobCh))
+		go func(j job) {
+			defer j.done()
+			bot.runJob(j.ctx, j.msg)
+		}(j)
+	}
+}
 
-			respMsg, err := routine.Execute(args)
-			if err != nil {
-				if msgErr := bot.SendMsgWithTimeout(err.Error(), reqMsg.Chat.Id, 5*time.Second); msgErr != nil {
-					fmt.Println(msgErr)
-				}
-				return
-			}
+// job is a message invoking a routine, with the context of its update.
+// done is called once the job has run.
+type job struct {
+	ctx  context.Context
+	msg  *api.Message
+	done func()
+}
 
-			if err = bot.SendMsgWithTimeout(respMsg, reqMsg.Chat.Id, 5*time.Second); err != nil {
-				fmt.Printf("unable to send message: %v ", err)
-			}
-		}(job)
+// dispatch passes update to its handler, or turns it into a job
+// if it is a message that invokes a routine.
+func (bot *TgramBot) dispatch(ctx context.Context, update api.Update, jobCh chan<- job) {
+	if bot.handleUpdate(ctx, update) {
+		return
+	}
+	if update.Message == nil || bot.handleMessage(ctx, update.Message) || !bot.admit(ctx, update.Message) {
+		return
+	}
+
+	jobCh <- job{ctx: ctx, msg: update.Message, done: startWork(ctx)}
+	bot.queued("jobs", len(jobCh))
+	bot.acknowledge(ctx, update.Message)
+}
+
+// runJob parses reqMsg, runs the routine it invokes and replies with its output.
+func (bot *TgramBot) runJob(ctx context.Context, reqMsg *api.Message) {
+	_, parseSpan := bot.tracer.Start(ctx, SpanParse)
+	routine, names, args, err := bot.resolve(reqMsg.Text)
+	parseSpan.RecordError(err)
+	parseSpan.End()
+	var unknown *UnknownCommandError
+	if errors.As(err, &unknown) {
+		if !isCommand(unknown.Command) {
+			return
+		}
+		if reply := bot.unknownReply(unknown.Command, unknown.Suggestion); reply != "" {
+			bot.reply(ctx, reply, reqMsg)
+		}
+		return
+	}
+	if err != nil {
+		bot.reply(ctx, err.Error(), reqMsg)
+		return
+	}
+
+	command := strings.Join(names, " ")
+	ctx, span := bot.tracer.Start(ctx, SpanRoutine,
+		Attr("tgram.routine", command), Attr("tgram.chat_id", reqMsg.Chat.Id))
+	defer span.End()
+
+	authCtx, authSpan := bot.tracer.Start(ctx, SpanAuthorize)
+	authCtx, cancel := context.WithTimeout(authCtx, 5*time.Second)
+	allowed := bot.authorize(authCtx, command, routine, reqMsg)
+	cancel()
+	authSpan.SetAttributes(Attr("tgram.allowed", allowed))
+	authSpan.End()
+	if !allowed {
+		return
+	}
+
+	stopChatAction := bot.showChatAction(ctx, routine, reqMsg)
+	defer stopChatAction()
+
+	start := time.Now()
+	respMsg, err := bot.execute(ctx, routine, reqMsg, args)
+	bot.metrics.routine(command, time.Since(start), err)
+	if err != nil {
+		span.RecordError(err)
+		bot.logger.Info("routine failed", "routine", command, "chat_id", reqMsg.Chat.Id,
+			"duration", time.Since(start), "error", err)
+		bot.reply(ctx, err.Error(), reqMsg)
+		return
+	}
+	bot.logger.Debug("routine executed", "routine", command, "chat_id", reqMsg.Chat.Id,
+		"duration", time.Since(start))
+
+	bot.reply(ctx, respMsg, reqMsg)
+}
+
+// queued records the depth of one of Run's queues.
+func (bot *TgramBot) queued(name string, depth int) {
+	bot.metrics.queue(name, depth)
+	bot.status.queue(name, depth)
+}
+
+// reply sends text to the chat of msg, logging any error.
+func (bot *TgramBot) reply(ctx context.Context, text string, msg *api.Message) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := bot.SendMsg(ctx, text, msg.Chat.Id); err != nil {
+		bot.logger.Error("unable to send message", "chat_id", msg.Chat.Id, "error", err)
 	}
 }
@@ -0,0 +1,450 @@
+package bot
+
+import (
+	"context"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultBroadcastRate stays below Telegram's limit of about
+	// 30 messages per second across all chats.
+	DefaultBroadcastRate        = 25
+	defaultBroadcastConcurrency = 4
+	defaultBroadcastRetries     = 3
+	defaultBroadcastFloodWaits  = 5
+	defaultBroadcastRetryDelay  = time.Second
+)
+
+// DeliveryStatus is the outcome of a broadcast for one recipient.
+type DeliveryStatus string
+
+const (
+	DeliveryPending DeliveryStatus = "pending"
+	DeliverySent    DeliveryStatus = "sent"
+	// DeliveryBlocked means the bot was blocked by the user, kicked from
+	// the chat, or the user's account was deleted.
+	DeliveryBlocked      DeliveryStatus = "blocked"
+	DeliveryChatNotFound DeliveryStatus = "chat_not_found"
+	// DeliveryFailed means Telegram rejected the message for another
+	// reason, or it kept failing after the configured retries.
+	DeliveryFailed DeliveryStatus = "failed"
+)
+
+// BroadcastFunc sends the broadcast message to one chat.
+type BroadcastFunc func(ctx context.Context, chatID int64) error
+
+// BroadcastConfig configures a Broadcast.
+// Rate is the number of messages sent per second, DefaultBroadcastRate if 0.
+// Concurrency is the number of sends in flight at once, 4 if 0.
+// Transient failures, i.e. network errors and 5xx responses, are retried up
+// to MaxRetries times, 3 if 0 and none if negative, waiting RetryDelay
+// (1s if 0) and then twice as long after each further failure.
+// Flood-limit responses are retried after the wait Telegram asks for,
+// during which the whole broadcast holds off, up to MaxFloodWaits times
+// per recipient, 5 if 0 and none if negative.
+// OnProgress, if set, is called after each recipient's outcome is settled.
+type BroadcastConfig struct {
+	Rate          float64
+	Concurrency   int
+	MaxRetries    int
+	MaxFloodWaits int
+	RetryDelay    time.Duration
+	OnProgress    func(BroadcastProgress)
+}
+
+// BroadcastResult is the outcome of a broadcast for one recipient.
+// Err is the last error returned for a recipient that was not sent to.
+type BroadcastResult struct {
+	ChatID   int64
+	Status   DeliveryStatus
+	Attempts int
+	Err      error
+}
+
+// BroadcastProgress summarizes a Broadcast.
+// Retries counts repeated attempts, including those after flood limits.
+type BroadcastProgress struct {
+	Total        int
+	Pending      int
+	Sent         int
+	Blocked      int
+	ChatNotFound int
+	Failed       int
+	Retries      int
+	Paused       bool
+	Cancelled    bool
+	Done         bool
+	Elapsed      time.Duration
+}
+
+// Broadcast sends a message to many chats in the background.
+// It is safe for concurrent use.
+type Broadcast struct {
+	bot    *TgramBot
+	send   BroadcastFunc
+	config BroadcastConfig
+	pacer  *pacer
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu       sync.Mutex
+	results  []BroadcastResult
+	progress BroadcastProgress
+	started  time.Time
+	finished time.Time
+	resumed  chan struct{}
+
+	// notifyMu serializes calls to OnProgress.
+	notifyMu sync.Mutex
+}
+
+// Broadcast starts sending to recipients in the background with send,
+// pacing the sends as set by config. Duplicate recipients are sent to once.
+// Cancelling ctx cancels the broadcast.
+// Recipients not sent to when a broadcast is cancelled stay DeliveryPending.
+func (bot *TgramBot) Broadcast(ctx context.Context, recipients []int64, send BroadcastFunc, config BroadcastConfig) *Broadcast {
+	if config.Rate <= 0 {
+		config.Rate = DefaultBroadcastRate
+	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = defaultBroadcastConcurrency
+	}
+	if config.MaxRetries == 0 {
+		config.MaxRetries = defaultBroadcastRetries
+	}
+	if config.MaxFloodWaits == 0 {
+		config.MaxFloodWaits = defaultBroadcastFloodWaits
+	}
+	if config.RetryDelay <= 0 {
+		config.RetryDelay = defaultBroadcastRetryDelay
+	}
+
+	seen := map[int64]bool{}
+	results := make([]BroadcastResult, 0, len(recipients))
+	for _, chatID := range recipients {
+		if !seen[chatID] {
+			seen[chatID] = true
+			results = append(results, BroadcastResult{ChatID: chatID, Status: DeliveryPending})
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	b := &Broadcast{
+		bot:      bot,
+		send:     send,
+		config:   config,
+		pacer:    &pacer{interval: time.Duration(float64(time.Second) / config.Rate)},
+		cancel:   cancel,
+		done:     make(chan struct{}),
+		results:  results,
+		progress: BroadcastProgress{Total: len(results), Pending: len(results)},
+		started:  time.Now(),
+	}
+	go b.run(ctx)
+	return b
+}
+
+// BroadcastMessage returns a BroadcastFunc that sends params to each chat,
+// with params.ChatID replaced by the recipient.
+func (bot *TgramBot) BroadcastMessage(params api.SendMessageParams) BroadcastFunc {
+	return func(ctx context.Context, chatID int64) error {
+		params := params
+		params.ChatID = chatID
+		_, err := bot.SendMessage(ctx, params)
+		return err
+	}
+}
+
+// Pause stops starting new sends until Resume is called.
+// Sends already in flight complete.
+func (b *Broadcast) Pause() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.resumed == nil && !b.progress.Done {
+		b.resumed = make(chan struct{})
+		b.progress.Paused = true
+	}
+}
+
+// Resume continues a paused broadcast.
+func (b *Broadcast) Resume() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.resumed != nil {
+		close(b.resumed)
+		b.resumed = nil
+		b.progress.Paused = false
+	}
+}
+
+// Cancel stops the broadcast. Sends already in flight complete.
+func (b *Broadcast) Cancel() {
+	b.mu.Lock()
+	if !b.progress.Done {
+		b.progress.Cancelled = true
+	}
+	b.mu.Unlock()
+	b.cancel()
+}
+
+// Done returns a channel that is closed when the broadcast finishes.
+func (b *Broadcast) Done() <-chan struct{} {
+	return b.done
+}
+
+// Wait blocks until the broadcast finishes and returns its final progress.
+func (b *Broadcast) Wait() BroadcastProgress {
+	<-b.done
+	return b.Progress()
+}
+
+// Progress returns a summary of the broadcast so far.
+func (b *Broadcast) Progress() BroadcastProgress {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.snapshot()
+}
+
+// Results returns the outcome for each recipient, in the order given.
+func (b *Broadcast) Results() []BroadcastResult {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]BroadcastResult(nil), b.results...)
+}
+
+// snapshot must be called with b.mu held.
+func (b *Broadcast) snapshot() BroadcastProgress {
+	progress := b.progress
+	end := b.finished
+	if end.IsZero() {
+		end = time.Now()
+	}
+	progress.Elapsed = end.Sub(b.started)
+	return progress
+}
+
+func (b *Broadcast) run(ctx context.Context) {
+	ctx, span := b.bot.tracer.Start(ctx, SpanBroadcast, Attr("tgram.recipients", len(b.results)))
+	defer span.End()
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < b.config.Concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				b.deliver(ctx, i)
+			}
+		}()
+	}
+
+feed:
+	for i := range b.results {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	b.mu.Lock()
+	b.finished = time.Now()
+	b.progress.Done = true
+	b.progress.Paused = false
+	if ctx.Err() != nil && b.progress.Pending > 0 {
+		b.progress.Cancelled = true
+	}
+	progress := b.snapshot()
+	b.mu.Unlock()
+	b.cancel()
+
+	b.bot.logger.Info("broadcast finished", "total", progress.Total, "sent", progress.Sent,
+		"blocked", progress.Blocked, "chat_not_found", progress.ChatNotFound, "failed", progress.Failed,
+		"pending", progress.Pending, "duration", progress.Elapsed)
+	b.notify(progress)
+	close(b.done)
+}
+
+// deliver sends to the recipient at index i, retrying as configured,
+// and records the outcome. It leaves the recipient pending if ctx ends first.
+func (b *Broadcast) deliver(ctx context.Context, i int) {
+	chatID := b.results[i].ChatID
+	retries, floodWaits := 0, 0
+	delay := b.config.RetryDelay
+	for attempt := 1; ; attempt++ {
+		if err := b.waitTurn(ctx); err != nil {
+			return
+		}
+		err := b.send(ctx, chatID)
+		if attempt > 1 {
+			b.mu.Lock()
+			b.progress.Retries++
+			b.mu.Unlock()
+		}
+		if err != nil && ctx.Err() != nil {
+			return
+		}
+
+		status, wait := classifyDelivery(err)
+		switch {
+		case status != DeliveryPending:
+		case wait > 0 && (b.config.MaxFloodWaits < 0 || floodWaits >= b.config.MaxFloodWaits):
+			status = DeliveryFailed
+		case wait > 0:
+			floodWaits++
+			// Flood limits apply to the whole bot, so everyone holds off.
+			b.pacer.hold(time.Now().Add(wait))
+			b.bot.metrics.retry("rate_limited")
+			continue
+		case b.config.MaxRetries < 0 || retries >= b.config.MaxRetries:
+			status = DeliveryFailed
+		default:
+			retries++
+			b.bot.metrics.retry("transient")
+			if sleep(ctx, delay) != nil {
+				return
+			}
+			delay *= 2
+			continue
+		}
+
+		b.settle(i, status, attempt, err)
+		return
+	}
+}
+
+// waitTurn blocks while the broadcast is paused and then until the pacer
+// allows another send.
+func (b *Broadcast) waitTurn(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		resumed := b.resumed
+		b.mu.Unlock()
+		if resumed == nil {
+			break
+		}
+		select {
+		case <-resumed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return b.pacer.wait(ctx)
+}
+
+func (b *Broadcast) settle(i int, status DeliveryStatus, attempts int, err error) {
+	b.mu.Lock()
+	result := &b.results[i]
+	result.Status, result.Attempts = status, attempts
+	if status != DeliverySent {
+		result.Err = err
+	}
+	b.progress.Pending--
+	switch status {
+	case DeliverySent:
+		b.progress.Sent++
+	case DeliveryBlocked:
+		b.progress.Blocked++
+	case DeliveryChatNotFound:
+		b.progress.ChatNotFound++
+	default:
+		b.progress.Failed++
+	}
+	progress := b.snapshot()
+	b.mu.Unlock()
+
+	if err != nil {
+		b.bot.logger.Debug("broadcast delivery failed", "chat_id", result.ChatID, "status", status, "error", err)
+	}
+	b.notify(progress)
+}
+
+func (b *Broadcast) notify(progress BroadcastProgress) {
+	if b.config.OnProgress == nil {
+		return
+	}
+	b.notifyMu.Lock()
+	defer b.notifyMu.Unlock()
+	b.config.OnProgress(progress)
+}
+
+// classifyDelivery maps the error of a send to its outcome.
+// It returns DeliveryPending for transient errors, with the wait Telegram
+// asked for if it was a flood limit.
+func classifyDelivery(err error) (DeliveryStatus, time.Duration) {
+	if err == nil {
+		return DeliverySent, 0
+	}
+	apiErr, ok := api.AsError(err)
+	if !ok {
+		return DeliveryPending, 0
+	}
+
+	switch {
+	case apiErr.Code == http.StatusTooManyRequests:
+		wait := apiErr.RetryAfter()
+		if wait <= 0 {
+			wait = time.Second
+		}
+		return DeliveryPending, wait
+	case apiErr.Code == http.StatusForbidden:
+		return DeliveryBlocked, 0
+	case strings.Contains(strings.ToLower(apiErr.Description), "chat not found"):
+		return DeliveryChatNotFound, 0
+	case apiErr.Code >= http.StatusInternalServerError:
+		return DeliveryPending, 0
+	}
+	return DeliveryFailed, 0
+}
+
+// pacer spaces out events by a fixed interval. It is safe for concurrent use.
+type pacer struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// wait blocks until the caller's turn comes or ctx is done.
+func (p *pacer) wait(ctx context.Context) error {
+	p.mu.Lock()
+	now := time.Now()
+	if p.next.Before(now) {
+		p.next = now
+	}
+	turn := p.next
+	p.next = p.next.Add(p.interval)
+	p.mu.Unlock()
+
+	return sleep(ctx, time.Until(turn))
+}
+
+// hold delays every turn until at least until.
+func (p *pacer) hold(until time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if until.After(p.next) {
+		p.next = until
+	}
+}
+
+// sleep waits for d or until ctx is done, whichever comes first.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,153 @@
+package bot_test
+
+import (
+	"context"
+	"errors"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bot"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bottest"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// scriptedSend returns a BroadcastFunc that fails for each chat with the
+// errors listed for it, in order, and then succeeds.
+func scriptedSend(script map[int64][]error) bot.BroadcastFunc {
+	var mu sync.Mutex
+	return func(_ context.Context, chatID int64) error {
+		mu.Lock()
+		defer mu.Unlock()
+		errs := script[chatID]
+		if len(errs) == 0 {
+			return nil
+		}
+		script[chatID] = errs[1:]
+		return errs[0]
+	}
+}
+
+func floodError() error {
+	return &api.Error{Code: http.StatusTooManyRequests, Description: "Too Many Requests",
+		Parameters: &api.ResponseParameters{RetryAfter: 1}}
+}
+
+func TestBroadcastOutcomes(t *testing.T) {
+	s := bottest.NewServer()
+	defer s.Close()
+	tgBot := s.Bot()
+
+	forbidden := &api.Error{Code: http.StatusForbidden, Description: "Forbidden: bot was blocked by the user"}
+	notFound := &api.Error{Code: http.StatusBadRequest, Description: "Bad Request: chat not found"}
+	badRequest := &api.Error{Code: http.StatusBadRequest, Description: "Bad Request: message is too long"}
+	serverError := &api.Error{Code: http.StatusBadGateway, Description: "Bad Gateway"}
+	network := errors.New("connection reset")
+
+	send := scriptedSend(map[int64][]error{
+		2: {forbidden},
+		3: {notFound},
+		4: {badRequest},
+		5: {serverError, network},
+		6: {serverError, serverError, serverError},
+		7: {floodError()},
+		8: {floodError(), floodError()},
+	})
+	b := tgBot.Broadcast(context.Background(), []int64{1, 2, 3, 4, 5, 6, 7, 8, 1}, send, bot.BroadcastConfig{
+		Rate:          1000,
+		MaxRetries:    2,
+		MaxFloodWaits: 1,
+		RetryDelay:    time.Millisecond,
+	})
+	progress := b.Wait()
+
+	want := []struct {
+		status   bot.DeliveryStatus
+		attempts int
+	}{
+		{bot.DeliverySent, 1},
+		{bot.DeliveryBlocked, 1},
+		{bot.DeliveryChatNotFound, 1},
+		{bot.DeliveryFailed, 1},
+		{bot.DeliverySent, 3},
+		{bot.DeliveryFailed, 3},
+		{bot.DeliverySent, 2},
+		{bot.DeliveryFailed, 2},
+	}
+	results := b.Results()
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want one per distinct recipient", len(results))
+	}
+	for i, result := range results {
+		if result.ChatID != int64(i+1) || result.Status != want[i].status || result.Attempts != want[i].attempts {
+			t.Errorf("result %d = %+v, want %s after %d attempts", i, result, want[i].status, want[i].attempts)
+		}
+		if (result.Status == bot.DeliverySent) != (result.Err == nil) {
+			t.Errorf("result %d has error %v with status %s", i, result.Err, result.Status)
+		}
+	}
+
+	if !progress.Done || progress.Pending != 0 || progress.Sent != 3 || progress.Blocked != 1 ||
+		progress.ChatNotFound != 1 || progress.Failed != 3 || progress.Retries != 6 {
+		t.Errorf("progress = %+v", progress)
+	}
+}
+
+func TestBroadcastPacing(t *testing.T) {
+	s := bottest.NewServer()
+	defer s.Close()
+	tgBot := s.Bot()
+
+	var mu sync.Mutex
+	var sends []time.Time
+	send := func(context.Context, int64) error {
+		mu.Lock()
+		defer mu.Unlock()
+		sends = append(sends, time.Now())
+		return nil
+	}
+
+	const rate = 20
+	progress := tgBot.Broadcast(context.Background(), []int64{1, 2, 3, 4, 5, 6}, send,
+		bot.BroadcastConfig{Rate: rate, Concurrency: 3}).Wait()
+	if progress.Sent != 6 {
+		t.Fatalf("progress = %+v, want 6 sent", progress)
+	}
+
+	interval := time.Second / rate
+	if elapsed := sends[len(sends)-1].Sub(sends[0]); elapsed < 5*interval-10*time.Millisecond {
+		t.Errorf("6 sends took %v, want at least %v at %d per second", elapsed, 5*interval, rate)
+	}
+}
+
+func TestBroadcastFloodLimitHoldsEveryone(t *testing.T) {
+	s := bottest.NewServer()
+	defer s.Close()
+	tgBot := s.Bot()
+
+	var mu sync.Mutex
+	sent := map[int64]time.Time{}
+	flooded := false
+	send := func(_ context.Context, chatID int64) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if chatID == 1 && !flooded {
+			flooded = true
+			return floodError()
+		}
+		sent[chatID] = time.Now()
+		return nil
+	}
+
+	start := time.Now()
+	progress := tgBot.Broadcast(context.Background(), []int64{1, 2, 3}, send,
+		bot.BroadcastConfig{Rate: 1000, Concurrency: 1}).Wait()
+	if progress.Sent != 3 || progress.Retries != 1 {
+		t.Fatalf("progress = %+v, want 3 sent after 1 retry", progress)
+	}
+	for chatID, at := range sent {
+		if at.Sub(start) < time.Second {
+			t.Errorf("chat %d was sent to %v after start, during the flood wait", chatID, at.Sub(start))
+		}
+	}
+}
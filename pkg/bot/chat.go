@@ -0,0 +1,134 @@
+package bot
+
+import (
+	"context"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+)
+
+// GetChat retrieves up to date information about a chat.
+func (bot *TgramBot) GetChat(ctx context.Context, chatID int64) (*api.Chat, error) {
+	chat := &api.Chat{}
+	if err := bot.Call(ctx, "getChat", api.ChatParams{ChatID: chatID}, chat); err != nil {
+		return nil, err
+	}
+	return chat, nil
+}
+
+// GetChatMemberCount returns the number of members in a chat.
+func (bot *TgramBot) GetChatMemberCount(ctx context.Context, chatID int64) (int, error) {
+	var count int
+	if err := bot.Call(ctx, "getChatMemberCount", api.ChatParams{ChatID: chatID}, &count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// LeaveChat makes the bot leave a group, supergroup or channel.
+func (bot *TgramBot) LeaveChat(ctx context.Context, chatID int64) error {
+	return bot.Call(ctx, "leaveChat", api.ChatParams{ChatID: chatID}, nil)
+}
+
+// SetChatPermissions sets the default permissions of all chat members.
+// The bot must be an administrator with the can_restrict_members right.
+func (bot *TgramBot) SetChatPermissions(ctx context.Context, params api.SetChatPermissionsParams) error {
+	return bot.Call(ctx, "setChatPermissions", params, nil)
+}
+
+// SetChatPhoto uploads a new profile photo for a chat.
+func (bot *TgramBot) SetChatPhoto(ctx context.Context, params api.SetChatPhotoParams) error {
+	return bot.Call(ctx, "setChatPhoto", params, nil)
+}
+
+// DeleteChatPhoto removes a chat's profile photo.
+func (bot *TgramBot) DeleteChatPhoto(ctx context.Context, chatID int64) error {
+	return bot.Call(ctx, "deleteChatPhoto", api.ChatParams{ChatID: chatID}, nil)
+}
+
+// SetChatTitle changes the title of a group, supergroup or channel.
+func (bot *TgramBot) SetChatTitle(ctx context.Context, params api.SetChatTitleParams) error {
+	return bot.Call(ctx, "setChatTitle", params, nil)
+}
+
+// SetChatDescription changes the description of a group, supergroup or channel.
+func (bot *TgramBot) SetChatDescription(ctx context.Context, params api.SetChatDescriptionParams) error {
+	return bot.Call(ctx, "setChatDescription", params, nil)
+}
+
+// SetChatAdministratorCustomTitle sets the custom title of an administrator
+// the bot promoted in a supergroup.
+func (bot *TgramBot) SetChatAdministratorCustomTitle(ctx context.Context, params api.SetChatAdministratorCustomTitleParams) error {
+	return bot.Call(ctx, "setChatAdministratorCustomTitle", params, nil)
+}
+
+// BanChatSenderChat bans a channel chat from posting in a supergroup or channel.
+func (bot *TgramBot) BanChatSenderChat(ctx context.Context, params api.SenderChatParams) error {
+	return bot.Call(ctx, "banChatSenderChat", params, nil)
+}
+
+// UnbanChatSenderChat lifts a ban set by BanChatSenderChat.
+func (bot *TgramBot) UnbanChatSenderChat(ctx context.Context, params api.SenderChatParams) error {
+	return bot.Call(ctx, "unbanChatSenderChat", params, nil)
+}
+
+// SetChatStickerSet sets the group sticker set of a supergroup.
+func (bot *TgramBot) SetChatStickerSet(ctx context.Context, params api.SetChatStickerSetParams) error {
+	return bot.Call(ctx, "setChatStickerSet", params, nil)
+}
+
+// DeleteChatStickerSet removes the group sticker set of a supergroup.
+func (bot *TgramBot) DeleteChatStickerSet(ctx context.Context, chatID int64) error {
+	return bot.Call(ctx, "deleteChatStickerSet", api.ChatParams{ChatID: chatID}, nil)
+}
+
+// PinChatMessage adds a message to the list of pinned messages in a chat.
+func (bot *TgramBot) PinChatMessage(ctx context.Context, params api.PinChatMessageParams) error {
+	return bot.Call(ctx, "pinChatMessage", params, nil)
+}
+
+// UnpinChatMessage removes a message from the list of pinned messages.
+// If params.MessageID is 0, the most recently pinned message is unpinned.
+func (bot *TgramBot) UnpinChatMessage(ctx context.Context, params api.UnpinChatMessageParams) error {
+	return bot.Call(ctx, "unpinChatMessage", params, nil)
+}
+
+// UnpinAllChatMessages clears the list of pinned messages in a chat.
+func (bot *TgramBot) UnpinAllChatMessages(ctx context.Context, chatID int64) error {
+	return bot.Call(ctx, "unpinAllChatMessages", api.ChatParams{ChatID: chatID}, nil)
+}
+
+// ExportChatInviteLink generates a new primary invite link for a chat,
+// revoking the previous one.
+// It returns the new invite link.
+func (bot *TgramBot) ExportChatInviteLink(ctx context.Context, chatID int64) (string, error) {
+	var link string
+	if err := bot.Call(ctx, "exportChatInviteLink", api.ChatParams{ChatID: chatID}, &link); err != nil {
+		return "", err
+	}
+	return link, nil
+}
+
+// CreateChatInviteLink creates an additional invite link for a chat.
+// It returns the new api.ChatInviteLink.
+func (bot *TgramBot) CreateChatInviteLink(ctx context.Context, params api.CreateChatInviteLinkParams) (*api.ChatInviteLink, error) {
+	return bot.callInviteLink(ctx, "createChatInviteLink", params)
+}
+
+// EditChatInviteLink edits a non-primary invite link created by the bot.
+// It returns the edited api.ChatInviteLink.
+func (bot *TgramBot) EditChatInviteLink(ctx context.Context, params api.EditChatInviteLinkParams) (*api.ChatInviteLink, error) {
+	return bot.callInviteLink(ctx, "editChatInviteLink", params)
+}
+
+// RevokeChatInviteLink revokes an invite link created by the bot.
+// It returns the revoked api.ChatInviteLink.
+func (bot *TgramBot) RevokeChatInviteLink(ctx context.Context, params api.RevokeChatInviteLinkParams) (*api.ChatInviteLink, error) {
+	return bot.callInviteLink(ctx, "revokeChatInviteLink", params)
+}
+
+func (bot *TgramBot) callInviteLink(ctx context.Context, method string, params interface{}) (*api.ChatInviteLink, error) {
+	link := &api.ChatInviteLink{}
+	if err := bot.Call(ctx, method, params, link); err != nil {
+		return nil, err
+	}
+	return link, nil
+}
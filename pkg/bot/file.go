@@ -0,0 +1,102 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// GetFile retrieves the metadata needed to download a file.
+// It accepts a context.Context and the file's ID.
+// It returns an api.File whose FilePath can be passed to FileURL or DownloadFile.
+func (bot *TgramBot) GetFile(ctx context.Context, fileID string) (*api.File, error) {
+	file := &api.File{}
+	if err := bot.Call(ctx, "getFile", api.GetFileParams{FileID: fileID}, file); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// FileURL returns the download URL of a file returned by GetFile.
+func (bot *TgramBot) FileURL(file *api.File) string {
+	key := bot.key
+	if bot.testEnv {
+		key += "/test"
+	}
+	return api.MakeFileEndpointStrWithBase(bot.baseURL, file.FilePath, key)
+}
+
+// DownloadFile writes the contents of a file returned by GetFile to w.
+// When the bot talks to a local Bot API server, FilePath is an absolute
+// path on the server's disk and the file is read from there directly.
+// Otherwise files larger than api.MaxDownloadSize are refused, and
+// a download is aborted once it passes that size.
+// It returns any error from fetching or copying the file.
+func (bot *TgramBot) DownloadFile(ctx context.Context, file *api.File, w io.Writer) error {
+	if bot.local && filepath.IsAbs(file.FilePath) {
+		f, err := os.Open(file.FilePath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(w, f)
+		return err
+	}
+
+	limit := api.MaxDownloadSize
+	if bot.local {
+		limit = -1
+	}
+	if limit >= 0 && int64(file.FileSize) > limit {
+		return fmt.Errorf("file %s exceeds the %d byte download limit", file.FileID, limit)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", bot.FileURL(file), nil)
+	if err != nil {
+		return err
+	}
+	if bot.userAgent != "" {
+		req.Header.Set("User-Agent", bot.userAgent)
+	}
+
+	response, err := bot.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		err := response.Body.Close()
+		if err != nil {
+			bot.logger.Warn("unable to close response body", "error", err)
+		}
+	}()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("unable to download file: %s", response.Status)
+	}
+
+	if limit < 0 {
+		_, err = io.Copy(w, response.Body)
+		return err
+	}
+	n, err := io.Copy(w, io.LimitReader(response.Body, limit+1))
+	if err != nil {
+		return err
+	}
+	if n > limit {
+		return fmt.Errorf("file %s exceeds the %d byte download limit", file.FileID, limit)
+	}
+	return nil
+}
+
+// SendDocument sends a general file to a chat.
+// Documents given by path are uploaded, or passed by path in local mode.
+// Uploads larger than UploadLimit are rejected before they complete.
+// It returns the sent api.Message.
+func (bot *TgramBot) SendDocument(ctx context.Context, params api.SendDocumentParams) (*api.Message, error) {
+	return bot.callMessage(ctx, "sendDocument", params)
+}
@@ -0,0 +1,116 @@
+package bot_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bottest"
+	"strings"
+	"testing"
+)
+
+func TestNestedUploadsMatchAttachNames(t *testing.T) {
+	s := bottest.NewServer()
+	defer s.Close()
+	tgBot := s.Bot()
+
+	params := struct {
+		ChatID int64            `json:"chat_id"`
+		Media  []api.InputMedia `json:"media"`
+	}{
+		ChatID: 1,
+		Media: []api.InputMedia{
+			&api.InputMediaPhoto{Media: api.FileReader("a.jpg", strings.NewReader("first"))},
+			&api.InputMediaPhoto{Media: api.FileID("known")},
+			&api.InputMediaPhoto{Media: api.FileReader("b.jpg", strings.NewReader("second"))},
+		},
+	}
+	if err := tgBot.Call(context.Background(), "sendMediaGroup", params, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	call := s.Calls("sendMediaGroup")[0]
+	var media []struct {
+		Media string `json:"media"`
+	}
+	if err := call.Decode("media", &media); err != nil {
+		t.Fatal(err)
+	}
+	if len(media) != 3 || media[1].Media != "known" {
+		t.Fatalf("media = %+v", media)
+	}
+	for _, i := range []int{0, 2} {
+		part, ok := strings.CutPrefix(media[i].Media, "attach://")
+		if !ok {
+			t.Fatalf("media[%d] = %q, want an attach:// reference", i, media[i].Media)
+		}
+		if call.Param(part) == "" {
+			t.Errorf("media[%d] references part %q, which was not uploaded", i, part)
+		}
+	}
+}
+
+func TestUploadsLeaveCallerFilesUnchanged(t *testing.T) {
+	s := bottest.NewServer()
+	defer s.Close()
+	tgBot := s.Bot()
+
+	photo := api.FileReader("a.jpg", strings.NewReader("photo"))
+	params := struct {
+		ChatID int64            `json:"chat_id"`
+		Media  []api.InputMedia `json:"media"`
+	}{
+		ChatID: 1,
+		Media:  []api.InputMedia{&api.InputMediaPhoto{Media: photo}},
+	}
+	if err := tgBot.Call(context.Background(), "sendMediaGroup", params, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	encoded, err := json.Marshal(photo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(encoded) != `"attach://a.jpg"` {
+		t.Errorf("caller's file marshals as %s after the call, want its own name", encoded)
+	}
+	if got := params.Media[0].(*api.InputMediaPhoto).Media; got != photo {
+		t.Errorf("caller's media was replaced by %p", got)
+	}
+}
+
+func TestTopLevelUploadUsesParamName(t *testing.T) {
+	s := bottest.NewServer()
+	defer s.Close()
+	tgBot := s.Bot()
+
+	_, err := tgBot.SendDocument(context.Background(), api.SendDocumentParams{
+		ChatID:   1,
+		Document: api.FileReader("report.txt", strings.NewReader("contents")),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Calls("sendDocument")[0].Param("document"); got != "attach://report.txt" {
+		t.Errorf("document part = %q, want the uploaded file", got)
+	}
+}
+
+func TestDownloadFileLimit(t *testing.T) {
+	s := bottest.NewServer()
+	defer s.Close()
+	tgBot := s.Bot()
+
+	file := s.AddFile("small", []byte("ok"))
+	var buf bytes.Buffer
+	if err := tgBot.DownloadFile(context.Background(), &file, &buf); err != nil || buf.String() != "ok" {
+		t.Fatalf("DownloadFile = %q, %v", buf.String(), err)
+	}
+
+	big := file
+	big.FileSize = int(api.MaxDownloadSize + 1)
+	if err := tgBot.DownloadFile(context.Background(), &big, &buf); err == nil {
+		t.Error("DownloadFile accepted a file over api.MaxDownloadSize")
+	}
+}
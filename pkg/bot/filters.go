@@ -0,0 +1,197 @@
+package bot
+
+import (
+	"context"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Filter reports whether a message should be passed to a MessageHandler.
+// Filters compose with And, Or and Not.
+type Filter func(msg *api.Message) bool
+
+// MessageHandler handles a message matched by a Filter.
+type MessageHandler func(ctx context.Context, msg *api.Message) error
+
+type messageHandler struct {
+	filter   Filter
+	priority int
+	handler  MessageHandler
+}
+
+// HandleMessages registers handler for messages matching filter.
+// Filter handlers only see messages that do not invoke a routine.
+// They are tried from the highest priority down, in registration order
+// among equal priorities, and only the first match handles a message;
+// messages no filter matches are processed as usual.
+func (bot *TgramBot) HandleMessages(filter Filter, priority int, handler MessageHandler) {
+	bot.handlers.messages = append(bot.handlers.messages, messageHandler{
+		filter:   filter,
+		priority: priority,
+		handler:  handler,
+	})
+	sort.SliceStable(bot.handlers.messages, func(i, j int) bool {
+		return bot.handlers.messages[i].priority > bot.handlers.messages[j].priority
+	})
+}
+
+// handleMessage passes msg to the first filter handler matching it,
+// unless msg invokes a routine.
+// It returns true if a handler took the message.
+func (bot *TgramBot) handleMessage(ctx context.Context, msg *api.Message) bool {
+	if len(bot.handlers.messages) == 0 {
+		return false
+	}
+	if _, _, ok := bot.lookup(strings.Split(msg.Text, " ")[0]); ok {
+		return false
+	}
+
+	for _, h := range bot.handlers.messages {
+		if h.filter(msg) {
+			handler := h.handler
+			log := bot.logger.With("chat_id", msg.Chat.Id)
+			bot.runHandler(ctx, log, "message", func(ctx context.Context) error {
+				return handler(ctx, msg)
+			})
+			return true
+		}
+	}
+	return false
+}
+
+// ID returns the bot's user ID, which is the part of its token before the colon.
+// It returns 0 if the token is malformed.
+func (bot *TgramBot) ID() int64 {
+	prefix, _, _ := strings.Cut(bot.key, ":")
+	id, _ := strconv.ParseInt(prefix, 10, 64)
+	return id
+}
+
+// ReplyToBot matches replies to messages sent by the bot.
+func (bot *TgramBot) ReplyToBot() Filter {
+	return ReplyTo(bot.ID())
+}
+
+// And matches messages matched by every filter.
+func And(filters ...Filter) Filter {
+	return func(msg *api.Message) bool {
+		for _, filter := range filters {
+			if !filter(msg) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or matches messages matched by any filter.
+func Or(filters ...Filter) Filter {
+	return func(msg *api.Message) bool {
+		for _, filter := range filters {
+			if filter(msg) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not matches messages filter does not match.
+func Not(filter Filter) Filter {
+	return func(msg *api.Message) bool {
+		return !filter(msg)
+	}
+}
+
+// Any matches every message.
+func Any(*api.Message) bool {
+	return true
+}
+
+// Regex matches messages whose text or caption matches re.
+func Regex(re *regexp.Regexp) Filter {
+	return func(msg *api.Message) bool {
+		return re.MatchString(msg.Text) || re.MatchString(msg.Caption)
+	}
+}
+
+// Match is like Regex but compiles pattern, panicking if it is invalid.
+func Match(pattern string) Filter {
+	return Regex(regexp.MustCompile(pattern))
+}
+
+// Keyword matches messages whose text or caption contains any of the
+// keywords, ignoring case.
+func Keyword(keywords ...string) Filter {
+	return func(msg *api.Message) bool {
+		text := strings.ToLower(msg.Text + "\n" + msg.Caption)
+		for _, keyword := range keywords {
+			if strings.Contains(text, strings.ToLower(keyword)) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// HasEntity matches messages whose text or caption has an entity of one
+// of the given types, e.g. "url", "text_link" or "mention".
+func HasEntity(types ...string) Filter {
+	return func(msg *api.Message) bool {
+		for _, entities := range [][]api.MessageEntity{msg.Entities, msg.CaptionEntities} {
+			for _, entity := range entities {
+				if contains(types, entity.Type) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// HasLink matches messages containing a URL or a text link.
+var HasLink = HasEntity("url", "text_link")
+
+// HasPhoto matches photo messages.
+func HasPhoto(msg *api.Message) bool {
+	return len(msg.Photo) > 0
+}
+
+// HasDocument matches messages with a document attached.
+func HasDocument(msg *api.Message) bool {
+	return msg.Document != nil
+}
+
+// HasSticker matches sticker messages.
+func HasSticker(msg *api.Message) bool {
+	return msg.Sticker != nil
+}
+
+// HasLocation matches messages sharing a location.
+func HasLocation(msg *api.Message) bool {
+	return msg.Location != nil
+}
+
+// Forwarded matches forwarded messages.
+func Forwarded(msg *api.Message) bool {
+	return msg.ForwardOrigin != nil
+}
+
+// ChatType matches messages in chats of the given types,
+// e.g. "private", "group", "supergroup" or "channel".
+func ChatType(types ...string) Filter {
+	return func(msg *api.Message) bool {
+		return msg.Chat != nil && contains(types, msg.Chat.Type)
+	}
+}
+
+// ReplyTo matches replies to messages sent by the user with the given ID.
+func ReplyTo(userID int64) Filter {
+	return func(msg *api.Message) bool {
+		reply := msg.ReplyToMessage
+		return reply != nil && reply.From != nil && reply.From.Id == userID
+	}
+}
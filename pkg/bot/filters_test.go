@@ -0,0 +1,133 @@
+package bot_test
+
+import (
+	"context"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bot"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bottest"
+	"testing"
+	"time"
+)
+
+func TestFilterComposition(t *testing.T) {
+	link := []api.MessageEntity{{Type: "url", Offset: 5, Length: 19}}
+	dealLink := &api.Message{Chat: &api.Chat{Type: "group"}, Text: "Deal https://example.com", Entities: link}
+	dealText := &api.Message{Chat: &api.Chat{Type: "group"}, Text: "Deal of the day"}
+	photo := &api.Message{Chat: &api.Chat{Type: "private"}, Photo: []api.PhotoSize{{FileID: "p"}}}
+	document := &api.Message{Chat: &api.Chat{Type: "supergroup"}, Document: &api.Document{FileID: "d"}}
+
+	dealWithLink := bot.And(bot.Keyword("deal"), bot.HasLink)
+	media := bot.Or(bot.HasPhoto, bot.HasDocument)
+	groupMedia := bot.And(media, bot.Not(bot.ChatType("private")))
+
+	tests := []struct {
+		name   string
+		filter bot.Filter
+		msg    *api.Message
+		want   bool
+	}{
+		{"and: all match", dealWithLink, dealLink, true},
+		{"and: one fails", dealWithLink, dealText, false},
+		{"and: none", bot.And(), dealText, true},
+		{"or: first matches", media, photo, true},
+		{"or: second matches", media, document, true},
+		{"or: none match", media, dealText, false},
+		{"or: none", bot.Or(), photo, false},
+		{"not: inverts a match", bot.Not(bot.HasPhoto), photo, false},
+		{"not: inverts a miss", bot.Not(bot.HasPhoto), dealText, true},
+		{"nested: group document", groupMedia, document, true},
+		{"nested: private photo", groupMedia, photo, false},
+		{"nested: group text", groupMedia, dealText, false},
+	}
+	for _, tt := range tests {
+		if got := tt.filter(tt.msg); got != tt.want {
+			t.Errorf("%s: filter = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// recordHandler returns a MessageHandler that sends name and the
+// message text to handled.
+func recordHandler(name string, handled chan<- [2]string) bot.MessageHandler {
+	return func(_ context.Context, msg *api.Message) error {
+		handled <- [2]string{name, msg.Text}
+		return nil
+	}
+}
+
+// collect receives n handled messages, keyed by text.
+func collect(t *testing.T, handled <-chan [2]string, n int) map[string]string {
+	t.Helper()
+	got := map[string]string{}
+	for i := 0; i < n; i++ {
+		select {
+		case h := <-handled:
+			got[h[1]] = h[0]
+		case <-time.After(5 * time.Second):
+			t.Fatalf("handled %d messages, want %d: %v", len(got), n, got)
+		}
+	}
+	return got
+}
+
+func TestFilterHandlerPriority(t *testing.T) {
+	s := bottest.NewServer()
+	defer s.Close()
+	tgBot := s.Bot(bot.WithAck(bot.AckConfig{Mode: bot.AckNone}))
+
+	handled := make(chan [2]string, 10)
+	tgBot.HandleMessages(bot.Any, 0, recordHandler("fallback", handled))
+	tgBot.HandleMessages(bot.Keyword("urgent"), 10, recordHandler("urgent", handled))
+	tgBot.HandleMessages(bot.Keyword("urgent", "help"), 10, recordHandler("help", handled))
+	tgBot.HandleMessages(bot.Keyword("help"), 5, recordHandler("low help", handled))
+
+	s.PushMessage(1, "urgent: server down")
+	s.PushMessage(1, "help me")
+	s.PushMessage(1, "hello")
+	go tgBot.Run()
+
+	got := collect(t, handled, 3)
+	want := map[string]string{
+		"urgent: server down": "urgent",
+		"help me":             "help",
+		"hello":               "fallback",
+	}
+	for text, name := range want {
+		if got[text] != name {
+			t.Errorf("%q handled by %q, want %q", text, got[text], name)
+		}
+	}
+	time.Sleep(50 * time.Millisecond)
+	if len(handled) != 0 {
+		t.Errorf("a message was handled twice: %v", <-handled)
+	}
+}
+
+func TestFiltersSkipRoutineInvocations(t *testing.T) {
+	s := bottest.NewServer()
+	defer s.Close()
+	tgBot := s.Bot(bot.WithAck(bot.AckConfig{Mode: bot.AckNone}))
+	if err := tgBot.RegisterRoutine("echo", echoRoutine()); err != nil {
+		t.Fatal(err)
+	}
+
+	handled := make(chan [2]string, 10)
+	tgBot.HandleMessages(bot.Any, 0, recordHandler("any", handled))
+
+	s.PushMessage(1, "/echo hi")
+	s.PushMessage(1, "echo plain")
+	s.PushMessage(1, "hello")
+	go tgBot.Run()
+
+	got := collect(t, handled, 1)
+	if got["hello"] != "any" {
+		t.Errorf("handled %v, want only the message that invokes no routine", got)
+	}
+	s.WaitForCall("sendMessage", 2, 5*time.Second)
+	s.AssertSent(t, 1, "hi")
+	s.AssertSent(t, 1, "plain")
+	time.Sleep(50 * time.Millisecond)
+	if len(handled) != 0 {
+		t.Errorf("a filter handler saw a routine invocation: %v", <-handled)
+	}
+}
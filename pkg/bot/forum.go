@@ -0,0 +1,76 @@
+package bot
+
+import (
+	"context"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+)
+
+// GetForumTopicIconStickers returns the custom emoji stickers
+// that can be used as forum topic icons.
+func (bot *TgramBot) GetForumTopicIconStickers(ctx context.Context) ([]api.Sticker, error) {
+	var stickers []api.Sticker
+	if err := bot.Call(ctx, "getForumTopicIconStickers", nil, &stickers); err != nil {
+		return nil, err
+	}
+	return stickers, nil
+}
+
+// CreateForumTopic creates a topic in a forum supergroup.
+// It returns the new api.ForumTopic.
+func (bot *TgramBot) CreateForumTopic(ctx context.Context, params api.CreateForumTopicParams) (*api.ForumTopic, error) {
+	topic := &api.ForumTopic{}
+	if err := bot.Call(ctx, "createForumTopic", params, topic); err != nil {
+		return nil, err
+	}
+	return topic, nil
+}
+
+// EditForumTopic changes the name and icon of a forum topic.
+func (bot *TgramBot) EditForumTopic(ctx context.Context, params api.EditForumTopicParams) error {
+	return bot.Call(ctx, "editForumTopic", params, nil)
+}
+
+// CloseForumTopic closes an open forum topic.
+func (bot *TgramBot) CloseForumTopic(ctx context.Context, params api.ForumTopicParams) error {
+	return bot.Call(ctx, "closeForumTopic", params, nil)
+}
+
+// ReopenForumTopic reopens a closed forum topic.
+func (bot *TgramBot) ReopenForumTopic(ctx context.Context, params api.ForumTopicParams) error {
+	return bot.Call(ctx, "reopenForumTopic", params, nil)
+}
+
+// DeleteForumTopic deletes a forum topic along with all its messages.
+func (bot *TgramBot) DeleteForumTopic(ctx context.Context, params api.ForumTopicParams) error {
+	return bot.Call(ctx, "deleteForumTopic", params, nil)
+}
+
+// UnpinAllForumTopicMessages clears the list of pinned messages in a forum topic.
+func (bot *TgramBot) UnpinAllForumTopicMessages(ctx context.Context, params api.ForumTopicParams) error {
+	return bot.Call(ctx, "unpinAllForumTopicMessages", params, nil)
+}
+
+// EditGeneralForumTopic renames the 'General' topic of a forum supergroup.
+func (bot *TgramBot) EditGeneralForumTopic(ctx context.Context, params api.EditGeneralForumTopicParams) error {
+	return bot.Call(ctx, "editGeneralForumTopic", params, nil)
+}
+
+// CloseGeneralForumTopic closes the 'General' topic of a forum supergroup.
+func (bot *TgramBot) CloseGeneralForumTopic(ctx context.Context, chatID int64) error {
+	return bot.Call(ctx, "closeGeneralForumTopic", api.ChatParams{ChatID: chatID}, nil)
+}
+
+// ReopenGeneralForumTopic reopens the 'General' topic of a forum supergroup.
+func (bot *TgramBot) ReopenGeneralForumTopic(ctx context.Context, chatID int64) error {
+	return bot.Call(ctx, "reopenGeneralForumTopic", api.ChatParams{ChatID: chatID}, nil)
+}
+
+// HideGeneralForumTopic hides the 'General' topic of a forum supergroup.
+func (bot *TgramBot) HideGeneralForumTopic(ctx context.Context, chatID int64) error {
+	return bot.Call(ctx, "hideGeneralForumTopic", api.ChatParams{ChatID: chatID}, nil)
+}
+
+// UnhideGeneralForumTopic unhides the 'General' topic of a forum supergroup.
+func (bot *TgramBot) UnhideGeneralForumTopic(ctx context.Context, chatID int64) error {
+	return bot.Call(ctx, "unhideGeneralForumTopic", api.ChatParams{ChatID: chatID}, nil)
+}
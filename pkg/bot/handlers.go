@@ -0,0 +1,97 @@
+package bot
+
+import (
+	"context"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+	"log/slog"
+	"time"
+)
+
+// handlerTimeout bounds how long an update handler may run.
+const handlerTimeout = 10 * time.Second
+
+// updateHandlers holds the handlers registered for non-message updates.
+type updateHandlers struct {
+	inlineQuery        InlineQueryHandler
+	chosenInlineResult ChosenInlineResultHandler
+	shippingQuery      ShippingQueryHandler
+	preCheckoutQuery   PreCheckoutQueryHandler
+	successfulPayment  SuccessfulPaymentHandler
+	poll               PollHandler
+	pollAnswer         PollAnswerHandler
+	chatJoinRequest    ChatJoinRequestHandler
+	chatMember         ChatMemberHandler
+	myChatMember       ChatMemberHandler
+	messages           []messageHandler
+}
+
+// handleUpdate passes an update to the handler registered for its type.
+// Each handler runs in its own goroutine with a context derived from ctx
+// and bounded by handlerTimeout.
+// It returns true if a handler took the update.
+func (bot *TgramBot) handleUpdate(ctx context.Context, update api.Update) bool {
+	log := bot.logger.With("update_id", update.UpdateId)
+	switch {
+	case update.InlineQuery != nil && bot.handlers.inlineQuery != nil:
+		bot.runHandler(ctx, log, "inline query", func(ctx context.Context) error {
+			return bot.answerInline(ctx, update.InlineQuery)
+		})
+	case update.ChosenInlineResult != nil && bot.handlers.chosenInlineResult != nil:
+		bot.runHandler(ctx, log, "chosen inline result", func(ctx context.Context) error {
+			return bot.handlers.chosenInlineResult(ctx, update.ChosenInlineResult)
+		})
+	case update.ShippingQuery != nil && bot.handlers.shippingQuery != nil:
+		bot.runHandler(ctx, log, "shipping query", func(ctx context.Context) error {
+			return bot.answerShipping(ctx, update.ShippingQuery)
+		})
+	case update.PreCheckoutQuery != nil && bot.handlers.preCheckoutQuery != nil:
+		bot.runHandler(ctx, log, "pre-checkout query", func(ctx context.Context) error {
+			return bot.answerPreCheckout(ctx, update.PreCheckoutQuery)
+		})
+	case update.Message != nil && update.Message.SuccessfulPayment != nil && bot.handlers.successfulPayment != nil:
+		bot.runHandler(ctx, log, "successful payment", func(ctx context.Context) error {
+			return bot.handlers.successfulPayment(ctx, update.Message)
+		})
+	case update.Poll != nil && bot.handlers.poll != nil:
+		bot.runHandler(ctx, log, "poll", func(ctx context.Context) error {
+			return bot.handlers.poll(ctx, update.Poll)
+		})
+	case update.PollAnswer != nil && bot.handlers.pollAnswer != nil:
+		bot.runHandler(ctx, log, "poll answer", func(ctx context.Context) error {
+			return bot.handlers.pollAnswer(ctx, update.PollAnswer)
+		})
+	case update.ChatJoinRequest != nil && bot.handlers.chatJoinRequest != nil:
+		bot.runHandler(ctx, log, "chat join request", func(ctx context.Context) error {
+			return bot.handleJoinRequest(ctx, update.ChatJoinRequest)
+		})
+	case update.ChatMember != nil && bot.handlers.chatMember != nil:
+		bot.runHandler(ctx, log, "chat member", func(ctx context.Context) error {
+			return bot.handlers.chatMember(ctx, update.ChatMember)
+		})
+	case update.MyChatMember != nil && bot.handlers.myChatMember != nil:
+		bot.runHandler(ctx, log, "my chat member", func(ctx context.Context) error {
+			return bot.handlers.myChatMember(ctx, update.MyChatMember)
+		})
+	default:
+		return false
+	}
+	return true
+}
+
+func (bot *TgramBot) runHandler(ctx context.Context, log *slog.Logger, kind string, handler func(ctx context.Context) error) {
+	done := startWork(ctx)
+	go func() {
+		defer done()
+		ctx, span := bot.tracer.Start(ctx, SpanHandler, Attr("tgram.handler", kind))
+		defer span.End()
+		ctx, cancel := context.WithTimeout(ctx, handlerTimeout)
+		defer cancel()
+		start := time.Now()
+		if err := handler(ctx); err != nil {
+			span.RecordError(err)
+			log.Error("handler failed", "handler", kind, "duration", time.Since(start), "error", err)
+			return
+		}
+		log.Debug("handler finished", "handler", kind, "duration", time.Since(start))
+	}()
+}
@@ -0,0 +1,233 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+	"net/http"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultMaxPollAge is how long Run may go without a successful getUpdates
+// before AdminHandler reports the bot as not live.
+const DefaultMaxPollAge = time.Minute
+
+// Status is a snapshot of a bot's runtime state, as served by AdminHandler.
+type Status struct {
+	// Running is true once Run has been called.
+	Running bool `json:"running"`
+	// Live is true while getUpdates keeps succeeding.
+	Live bool `json:"live"`
+	// Ready is true once the bot's token was validated with getMe.
+	Ready     bool      `json:"ready"`
+	StartedAt time.Time `json:"started_at"`
+	// LastPoll is the time of the last successful getUpdates.
+	LastPoll      time.Time `json:"last_poll"`
+	LastPollError string    `json:"last_poll_error,omitempty"`
+	// LastUpdate is the time the last update was processed.
+	LastUpdate time.Time      `json:"last_update"`
+	Offset     int            `json:"offset"`
+	Queues     map[string]int `json:"queues"`
+	// Routines lists the hooks registered when Run started.
+	Routines []string  `json:"routines"`
+	Bot      *api.User `json:"bot,omitempty"`
+	Build    BuildInfo `json:"build"`
+}
+
+// BuildInfo describes the binary the bot runs in.
+type BuildInfo struct {
+	GoVersion string `json:"go_version"`
+	Path      string `json:"path,omitempty"`
+	Version   string `json:"version,omitempty"`
+	Revision  string `json:"revision,omitempty"`
+	Time      string `json:"time,omitempty"`
+	Modified  bool   `json:"modified,omitempty"`
+}
+
+// AdminConfig configures AdminHandler.
+// MaxPollAge defaults to DefaultMaxPollAge.
+type AdminConfig struct {
+	MaxPollAge time.Duration
+}
+
+// runtimeStatus records the progress of Run for Status.
+type runtimeStatus struct {
+	mu            sync.Mutex
+	startedAt     time.Time
+	lastPoll      time.Time
+	lastPollError string
+	lastUpdate    time.Time
+	offset        int
+	queues        map[string]int
+	routines      []string
+	me            *api.User
+}
+
+// start records the start of Run with the hooks registered at that time,
+// since the registry itself must not be read while Run is going.
+func (s *runtimeStatus) start(routines []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.startedAt = time.Now()
+	s.routines = routines
+}
+
+func (s *runtimeStatus) polled(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		s.lastPollError = err.Error()
+		return
+	}
+	s.lastPoll = time.Now()
+	s.lastPollError = ""
+}
+
+func (s *runtimeStatus) processed(offset int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastUpdate = time.Now()
+	s.offset = offset
+}
+
+func (s *runtimeStatus) queue(name string, depth int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.queues == nil {
+		s.queues = map[string]int{}
+	}
+	s.queues[name] = depth
+}
+
+func (s *runtimeStatus) validated(me *api.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.me = me
+}
+
+func (s *runtimeStatus) ready() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.me != nil
+}
+
+// validateToken calls getMe, marking the bot ready if it succeeds.
+func (bot *TgramBot) validateToken(ctx context.Context) {
+	me, err := bot.GetMe(ctx)
+	if err != nil {
+		bot.logger.Error("unable to validate token", "error", err)
+		return
+	}
+	bot.status.validated(me)
+}
+
+// Status returns a snapshot of the bot's runtime state.
+// A bot is live while its last successful getUpdates, or the start of Run
+// if none succeeded yet, is at most maxPollAge old.
+func (bot *TgramBot) Status(maxPollAge time.Duration) Status {
+	if maxPollAge <= 0 {
+		maxPollAge = DefaultMaxPollAge
+	}
+
+	s := &bot.status
+	s.mu.Lock()
+	status := Status{
+		Running:       !s.startedAt.IsZero(),
+		Ready:         s.me != nil,
+		StartedAt:     s.startedAt,
+		LastPoll:      s.lastPoll,
+		LastPollError: s.lastPollError,
+		LastUpdate:    s.lastUpdate,
+		Offset:        s.offset,
+		Queues:        map[string]int{},
+		Routines:      append([]string(nil), s.routines...),
+		Bot:           s.me,
+	}
+	for name, depth := range s.queues {
+		status.Queues[name] = depth
+	}
+	s.mu.Unlock()
+
+	lastAlive := status.LastPoll
+	if lastAlive.IsZero() {
+		lastAlive = status.StartedAt
+	}
+	status.Live = status.Running && time.Since(lastAlive) <= maxPollAge
+	status.Build = buildInfo()
+	return status
+}
+
+// AdminHandler returns an http.Handler for monitoring a running bot.
+// It serves:
+//
+//	/healthz  200 while the bot is live, 503 otherwise (liveness probe)
+//	/readyz   200 once the bot's token was validated, 503 before (readiness probe)
+//	/status   the bot's Status as JSON
+//
+// Mount it under a prefix with http.StripPrefix.
+func (bot *TgramBot) AdminHandler(config AdminConfig) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		status := bot.Status(config.MaxPollAge)
+		writeProbe(w, status.Live, "not live")
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, _ *http.Request) {
+		status := bot.Status(config.MaxPollAge)
+		writeProbe(w, status.Ready && status.Running, "not ready")
+	})
+	mux.HandleFunc("/status", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(bot.Status(config.MaxPollAge))
+	})
+	return mux
+}
+
+func writeProbe(w http.ResponseWriter, ok bool, failure string) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if !ok {
+		http.Error(w, failure, http.StatusServiceUnavailable)
+		return
+	}
+	_, _ = w.Write([]byte("ok\n"))
+}
+
+// routineHooks lists the hooks in registry, including the full command
+// of every subcommand, e.g. "admin ban".
+func routineHooks(registry RoutineRegistry, prefix string) []string {
+	hooks := make([]string, 0, len(registry))
+	for hook, routine := range registry {
+		hooks = append(hooks, prefix+hook)
+		hooks = append(hooks, routineHooks(routine.Subcommands, prefix+hook+" ")...)
+	}
+	sort.Strings(hooks)
+	return hooks
+}
+
+func buildInfo() BuildInfo {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return BuildInfo{}
+	}
+
+	build := BuildInfo{
+		GoVersion: info.GoVersion,
+		Path:      info.Main.Path,
+		Version:   info.Main.Version,
+	}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			build.Revision = setting.Value
+		case "vcs.time":
+			build.Time = setting.Value
+		case "vcs.modified":
+			build.Modified = setting.Value == "true"
+		}
+	}
+	return build
+}
@@ -0,0 +1,59 @@
+package bot_test
+
+import (
+	"encoding/json"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bot"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bottest"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAdminHandler(t *testing.T) {
+	s := bottest.NewServer()
+	defer s.Close()
+	tgBot := s.Bot()
+
+	admin := bot.NewGroup("Administration")
+	if err := admin.AddSubcommand("ban", echoRoutine()); err != nil {
+		t.Fatal(err)
+	}
+	if err := tgBot.RegisterRoutine("admin", admin); err != nil {
+		t.Fatal(err)
+	}
+	handler := tgBot.AdminHandler(bot.AdminConfig{})
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+	if code := get("/healthz").Code; code != http.StatusServiceUnavailable {
+		t.Errorf("/healthz before Run = %d, want 503", code)
+	}
+
+	go tgBot.Run()
+	deadline := time.Now().Add(5 * time.Second)
+	for get("/readyz").Code != http.StatusOK {
+		if time.Now().After(deadline) {
+			t.Fatal("bot never became ready")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if code := get("/healthz").Code; code != http.StatusOK {
+		t.Errorf("/healthz while running = %d, want 200", code)
+	}
+
+	var status bot.Status
+	if err := json.NewDecoder(get("/status").Body).Decode(&status); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"admin", "admin ban"}; !reflect.DeepEqual(status.Routines, want) {
+		t.Errorf("routines = %v, want %v", status.Routines, want)
+	}
+	if status.Bot == nil || status.Bot.Id != s.Me.Id {
+		t.Errorf("status bot = %+v, want the validated bot user", status.Bot)
+	}
+}
@@ -0,0 +1,261 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// validCommand matches the command names Telegram accepts in menus.
+var validCommand = regexp.MustCompile(`^[a-z0-9_]{1,32}$`)
+
+// maxCommandDescription is the longest command description, in characters,
+// Telegram accepts in menus.
+const maxCommandDescription = 256
+
+// RegisterHelp registers a built-in routine under hook that describes
+// the bot's routines. Invoked alone it lists every routine with its
+// description; invoked with a routine's hook, optionally followed by
+// subcommands, it shows that routine's usage, parameters and subcommands.
+// It returns an error if the hook name is already taken.
+func (bot *TgramBot) RegisterHelp(hook string) error {
+	help := func(args ...string) (string, error) {
+		if len(args) == 0 {
+			return bot.HelpText(), nil
+		}
+		return bot.RoutineHelp(strings.Join(args, " "))
+	}
+
+	routine := NewRoutine(Action{
+		Raw: help,
+		Wrapper: func(i ...interface{}) (string, error) {
+			args := make([]string, len(i))
+			for n := range i {
+				args[n] = i[n].(string)
+			}
+			return help(args...)
+		},
+	})
+	routine.Params = []string{"command"}
+	routine.Description = "Show available commands"
+	routine.Usage = "/" + commandName(hook) + " [command]"
+
+	return bot.RegisterRoutine(hook, routine)
+}
+
+// HelpText lists every registered routine with its signature and description,
+// sorted by hook.
+func (bot *TgramBot) HelpText() string {
+	hooks := make([]string, 0, len(bot.Registry))
+	for hook := range bot.Registry {
+		hooks = append(hooks, hook)
+	}
+	sort.Strings(hooks)
+
+	var b strings.Builder
+	b.WriteString("Available commands:")
+	for _, hook := range hooks {
+		routine := bot.Registry[hook]
+		b.WriteString("\n" + signature(hook, routine))
+		if routine.Description != "" {
+			b.WriteString(" - " + routine.Description)
+		}
+	}
+	return b.String()
+}
+
+// RoutineHelp describes the routine named by command, a hook optionally
+// followed by subcommands, e.g. "admin ban": its signature, description,
+// usage, aliases and subcommands.
+// It returns an error if no routine is registered for command.
+func (bot *TgramBot) RoutineHelp(command string) (string, error) {
+	names := strings.Fields(command)
+	if len(names) == 0 {
+		return "", fmt.Errorf("routine not found")
+	}
+	hook, routine, ok := find(bot.Registry, commandName(names[0]), true, bot.prefixMatching)
+	if !ok {
+		return "", fmt.Errorf("routine not found")
+	}
+	path := []string{hook}
+	for _, name := range names[1:] {
+		key, sub, ok := find(routine.Subcommands, name, true, bot.prefixMatching)
+		if !ok {
+			return "", fmt.Errorf("unknown subcommand %q", name)
+		}
+		path = append(path, key)
+		routine = sub
+	}
+
+	name := strings.Join(path, " ")
+	lines := []string{signature(name, routine)}
+	if routine.Description != "" {
+		lines = append(lines, routine.Description)
+	}
+	if routine.Usage != "" {
+		lines = append(lines, "Usage: "+routine.Usage)
+	}
+	if len(routine.Aliases) > 0 {
+		aliases := make([]string, len(routine.Aliases))
+		for i, alias := range routine.Aliases {
+			aliases[i] = "/" + commandName(alias)
+		}
+		lines = append(lines, "Aliases: "+strings.Join(aliases, ", "))
+	}
+	if len(routine.Subcommands) > 0 {
+		lines = append(lines, "Subcommands:")
+		for _, sub := range subcommandNames(routine) {
+			line := signature(name+" "+sub, routine.Subcommands[sub])
+			if description := routine.Subcommands[sub].Description; description != "" {
+				line += " - " + description
+			}
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// signature renders a routine's hook and parameter names, e.g. "/echo <msg>".
+// The last parameter of a variadic Action is optional and shown as "[name]",
+// and a command group without an Action takes a "<subcommand>".
+func signature(hook string, routine *Routine) string {
+	variadic := false
+	if fnType := reflect.TypeOf(routine.Action.Raw); fnType != nil && fnType.Kind() == reflect.Func {
+		variadic = fnType.IsVariadic()
+	}
+
+	parts := []string{"/" + commandName(hook)}
+	for i, param := range routine.Params {
+		if variadic && i == len(routine.Params)-1 {
+			parts = append(parts, "["+param+"]")
+		} else {
+			parts = append(parts, "<"+param+">")
+		}
+	}
+	if routine.Action.Raw == nil && len(routine.Subcommands) > 0 {
+		parts = append(parts, "<subcommand>")
+	}
+	return strings.Join(parts, " ")
+}
+
+// PublishCommands syncs Telegram's command menu with the registry.
+// Commands are set for each scope passed to WithCommandMenu, or the default
+// scope if none were, and for each scope named in a Routine's Scopes.
+// A menu holds the routines without Scopes plus those listing its scope.
+// Each menu is also set for every language found in LocalizedDescriptions.
+// Routines without a Description, or whose hook is not a valid Telegram
+// command, are left out. Descriptions longer than Telegram's limit of
+// 256 characters are truncated.
+func (bot *TgramBot) PublishCommands(ctx context.Context) error {
+	scopes := bot.commandScopes
+	if len(scopes) == 0 {
+		scopes = []api.BotCommandScope{nil}
+	}
+
+	languages := []string{""}
+	seenLanguage := map[string]bool{"": true}
+	for _, routine := range bot.Registry {
+		for lang := range routine.LocalizedDescriptions {
+			if !seenLanguage[lang] {
+				seenLanguage[lang] = true
+				languages = append(languages, lang)
+			}
+		}
+		scopes = append(scopes, routine.Scopes...)
+	}
+	sort.Strings(languages[1:])
+
+	published := map[string]bool{}
+	for _, scope := range scopes {
+		key, err := scopeKey(scope)
+		if err != nil {
+			return err
+		}
+		if published[key] {
+			continue
+		}
+		published[key] = true
+
+		for _, lang := range languages {
+			params := api.SetMyCommandsParams{
+				Commands:     bot.menu(key, lang),
+				Scope:        scope,
+				LanguageCode: lang,
+			}
+			if err := bot.SetMyCommands(ctx, params); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// menu returns the commands shown in the scope identified by key, in language lang.
+func (bot *TgramBot) menu(key, lang string) []api.BotCommand {
+	hooks := make([]string, 0, len(bot.Registry))
+	for hook := range bot.Registry {
+		hooks = append(hooks, hook)
+	}
+	sort.Strings(hooks)
+
+	commands := []api.BotCommand{}
+	for _, hook := range hooks {
+		routine := bot.Registry[hook]
+		name := commandName(hook)
+		if routine.Description == "" || !validCommand.MatchString(name) || !inScope(routine, key) {
+			continue
+		}
+
+		description := menuDescription(routine.Description)
+		if localized := menuDescription(routine.LocalizedDescriptions[lang]); localized != "" {
+			description = localized
+		}
+		if description == "" {
+			continue
+		}
+		commands = append(commands, api.BotCommand{Command: name, Description: description})
+	}
+	return commands
+}
+
+// menuDescription trims description and truncates it to maxCommandDescription
+// characters, ending it with an ellipsis if anything was cut.
+// It returns "" for a blank description.
+func menuDescription(description string) string {
+	description = strings.TrimSpace(description)
+	runes := []rune(description)
+	if len(runes) <= maxCommandDescription {
+		return description
+	}
+	return strings.TrimSpace(string(runes[:maxCommandDescription-1])) + "…"
+}
+
+func inScope(routine *Routine, key string) bool {
+	if len(routine.Scopes) == 0 {
+		return true
+	}
+	for _, scope := range routine.Scopes {
+		if k, err := scopeKey(scope); err == nil && k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// scopeKey identifies a scope by its JSON encoding, so scopes built
+// separately but describing the same chats compare equal.
+func scopeKey(scope api.BotCommandScope) (string, error) {
+	if scope == nil {
+		scope = &api.BotCommandScopeDefault{}
+	}
+	key, err := json.Marshal(scope)
+	if err != nil {
+		return "", err
+	}
+	return string(key), nil
+}
@@ -0,0 +1,57 @@
+package bot_test
+
+import (
+	"context"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bottest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestPublishCommandsLimitsDescriptions(t *testing.T) {
+	s := bottest.NewServer()
+	defer s.Close()
+	tgBot := s.Bot()
+
+	long := echoRoutine()
+	long.Description = strings.Repeat("ä", 300)
+	blank := echoRoutine()
+	blank.Description = "   "
+	localized := echoRoutine()
+	localized.Description = "Say something"
+	localized.LocalizedDescriptions = map[string]string{"de": " "}
+	if err := tgBot.RegisterRoutine("long", long); err != nil {
+		t.Fatal(err)
+	}
+	if err := tgBot.RegisterRoutine("blank", blank); err != nil {
+		t.Fatal(err)
+	}
+	if err := tgBot.RegisterRoutine("localized", localized); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tgBot.PublishCommands(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := s.Calls("setMyCommands")
+	if len(calls) != 2 {
+		t.Fatalf("setMyCommands called %d times, want once per language", len(calls))
+	}
+	for _, call := range calls {
+		var commands []api.BotCommand
+		if err := call.Decode("commands", &commands); err != nil {
+			t.Fatal(err)
+		}
+		if len(commands) != 2 || commands[0].Command != "localized" || commands[1].Command != "long" {
+			t.Fatalf("%s commands = %+v, want localized and long", call.Param("language_code"), commands)
+		}
+		if commands[0].Description != "Say something" {
+			t.Errorf("blank localized description = %q, want the default", commands[0].Description)
+		}
+		if n := utf8.RuneCountInString(commands[1].Description); n != 256 || !strings.HasSuffix(commands[1].Description, "…") {
+			t.Errorf("long description has %d characters, want 256 ending in an ellipsis", n)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+	"strconv"
+)
+
+// MaxInlineResults is the most results Telegram accepts in one answer.
+const MaxInlineResults = 50
+
+// InlineAnswer is the reply to an inline query.
+// NextOffset is sent back by Telegram in InlineQuery.Offset when the user
+// scrolls to the end of Results; leave it empty if there are no more.
+// CacheTime is how long, in seconds, Telegram may cache the results;
+// nil keeps Telegram's default of 300. IsPersonal caches them per user.
+type InlineAnswer struct {
+	Results    []api.InlineQueryResult
+	NextOffset string
+	CacheTime  *int
+	IsPersonal bool
+	Button     *api.InlineQueryResultsButton
+}
+
+// InlineQueryHandler produces the answer to an inline query.
+// Returning a nil answer leaves the query unanswered.
+type InlineQueryHandler func(ctx context.Context, query *api.InlineQuery) (*InlineAnswer, error)
+
+// ChosenInlineResultHandler is called when a user picks one of the bot's
+// inline results. It requires inline feedback to be enabled in BotFather.
+type ChosenInlineResultHandler func(ctx context.Context, result *api.ChosenInlineResult) error
+
+// HandleInlineQuery registers the handler for inline queries,
+// i.e. messages of the form "@ourbot query" typed in any chat.
+// The handler's answer is sent with AnswerInlineQuery.
+func (bot *TgramBot) HandleInlineQuery(handler InlineQueryHandler) {
+	bot.handlers.inlineQuery = handler
+}
+
+// HandleChosenInlineResult registers the handler for chosen inline results.
+func (bot *TgramBot) HandleChosenInlineResult(handler ChosenInlineResultHandler) {
+	bot.handlers.chosenInlineResult = handler
+}
+
+// AnswerInlineQuery sends the results of an inline query.
+// It refuses answers with more than MaxInlineResults results, which
+// Telegram would reject; PageInlineResults splits longer lists.
+func (bot *TgramBot) AnswerInlineQuery(ctx context.Context, params api.AnswerInlineQueryParams) error {
+	if len(params.Results) > MaxInlineResults {
+		return fmt.Errorf("%d inline results exceed the limit of %d per answer", len(params.Results), MaxInlineResults)
+	}
+	if params.Results == nil {
+		params.Results = []api.InlineQueryResult{}
+	}
+	return bot.Call(ctx, "answerInlineQuery", params, nil)
+}
+
+func (bot *TgramBot) answerInline(ctx context.Context, query *api.InlineQuery) error {
+	answer, err := bot.handlers.inlineQuery(ctx, query)
+	if err != nil || answer == nil {
+		return err
+	}
+
+	return bot.AnswerInlineQuery(ctx, api.AnswerInlineQueryParams{
+		InlineQueryID: query.ID,
+		Results:       answer.Results,
+		CacheTime:     answer.CacheTime,
+		IsPersonal:    answer.IsPersonal,
+		NextOffset:    answer.NextOffset,
+		Button:        answer.Button,
+	})
+}
+
+// PageInlineResults splits results into pages for answering an inline query.
+// It accepts all results, the query's Offset and the page size,
+// which is capped at MaxInlineResults.
+// It returns the page starting at offset and the offset of the next page,
+// which is empty once the last page is reached.
+func PageInlineResults(results []api.InlineQueryResult, offset string, pageSize int) ([]api.InlineQueryResult, string) {
+	if pageSize <= 0 || pageSize > MaxInlineResults {
+		pageSize = MaxInlineResults
+	}
+
+	start, err := strconv.Atoi(offset)
+	if err != nil || start < 0 {
+		start = 0
+	}
+	if start >= len(results) {
+		return []api.InlineQueryResult{}, ""
+	}
+
+	end := start + pageSize
+	if end >= len(results) {
+		return results[start:], ""
+	}
+	return results[start:end], strconv.Itoa(end)
+}
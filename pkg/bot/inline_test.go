@@ -0,0 +1,56 @@
+package bot_test
+
+import (
+	"context"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bot"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bottest"
+	"strconv"
+	"testing"
+)
+
+func inlineResults(n int) []api.InlineQueryResult {
+	results := make([]api.InlineQueryResult, n)
+	for i := range results {
+		results[i] = &api.InlineQueryResultArticle{ID: strconv.Itoa(i), Title: "result"}
+	}
+	return results
+}
+
+func TestAnswerInlineQueryLimit(t *testing.T) {
+	s := bottest.NewServer()
+	defer s.Close()
+	tgBot := s.Bot()
+
+	err := tgBot.AnswerInlineQuery(context.Background(), api.AnswerInlineQueryParams{
+		InlineQueryID: "q",
+		Results:       inlineResults(bot.MaxInlineResults + 1),
+	})
+	if err == nil {
+		t.Fatal("AnswerInlineQuery accepted more than MaxInlineResults results")
+	}
+	if calls := s.Calls("answerInlineQuery"); len(calls) != 0 {
+		t.Errorf("oversized answer reached the API %d times", len(calls))
+	}
+
+	err = tgBot.AnswerInlineQuery(context.Background(), api.AnswerInlineQueryParams{
+		InlineQueryID: "q",
+		Results:       inlineResults(bot.MaxInlineResults),
+	})
+	if err != nil {
+		t.Fatalf("AnswerInlineQuery with MaxInlineResults results: %v", err)
+	}
+}
+
+func TestPageInlineResults(t *testing.T) {
+	results := inlineResults(120)
+
+	page, next := bot.PageInlineResults(results, "", 0)
+	if len(page) != bot.MaxInlineResults || next != "50" {
+		t.Fatalf("first page has %d results and next offset %q", len(page), next)
+	}
+	page, next = bot.PageInlineResults(results, "100", 50)
+	if len(page) != 20 || next != "" {
+		t.Fatalf("last page has %d results and next offset %q", len(page), next)
+	}
+}
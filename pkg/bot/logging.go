@@ -0,0 +1,20 @@
+package bot
+
+import (
+	"context"
+	"log/slog"
+)
+
+// discardHandler is a slog.Handler that drops every record.
+// It keeps the library silent unless WithLogger is given.
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+// Logger returns the logger the bot writes to.
+func (bot *TgramBot) Logger() *slog.Logger {
+	return bot.logger
+}
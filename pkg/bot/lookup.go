@@ -0,0 +1,147 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxSuggestionDistance is the largest edit distance at which an unknown
+// command is assumed to be a typo of a registered one.
+const maxSuggestionDistance = 2
+
+// UnknownCommandError is returned by ParseMessage when a message does not
+// start with a registered hook. Suggestion is the closest registered
+// command, e.g. "/echo", or "" if none is close enough.
+type UnknownCommandError struct {
+	Command    string
+	Suggestion string
+}
+
+func (e *UnknownCommandError) Error() string {
+	return "routine not found"
+}
+
+// defaultUnknownCommandReply is the reply to unknown commands
+// used unless WithUnknownCommandReply says otherwise.
+func defaultUnknownCommandReply(command, suggestion string) string {
+	if suggestion == "" {
+		return fmt.Sprintf("Unknown command %q.", command)
+	}
+	return fmt.Sprintf("Unknown command %q. Did you mean %s?", command, suggestion)
+}
+
+// commandName strips the leading slash and any @botname suffix from a hook
+// or the first word of a message, e.g. "/help@our_bot" becomes "help".
+func commandName(word string) string {
+	name := strings.TrimPrefix(word, "/")
+	name, _, _ = strings.Cut(name, "@")
+	return name
+}
+
+// isCommand reports whether msg is a command, i.e. starts with a slash.
+func isCommand(msg string) bool {
+	return strings.HasPrefix(msg, "/")
+}
+
+// lookup finds the routine invoked by the first word of a message and
+// returns the hook it is registered under.
+// Hooks registered with or without a leading slash match both forms,
+// and a trailing @botname, as sent from group chats, is ignored.
+// Only commands match Aliases and, with WithPrefixMatching, prefixes;
+// other words must name a hook. See find for the matching rules.
+func (bot *TgramBot) lookup(word string) (string, *Routine, bool) {
+	if routine, ok := bot.Registry[word]; ok {
+		return word, routine, true
+	}
+	command := isCommand(word)
+	return find(bot.Registry, commandName(word), command, command && bot.prefixMatching)
+}
+
+// find looks name up in registry, comparing names without a leading slash
+// and ignoring case, and returns the key the match is registered under.
+// Routines match their hook and, with aliases set, any of their Aliases.
+// With prefix set, a name that is the prefix of exactly one routine's
+// names also matches it.
+func find(registry RoutineRegistry, name string, aliases, prefix bool) (string, *Routine, bool) {
+	name = strings.ToLower(name)
+	var candidate *Routine
+	var candidateHook string
+	ambiguous := false
+	for hook, routine := range registry {
+		names := routineNames(hook, routine)
+		if !aliases {
+			names = names[:1]
+		}
+		for _, n := range names {
+			if n == name {
+				return hook, routine, true
+			}
+			if prefix && name != "" && strings.HasPrefix(n, name) {
+				if candidate != nil && candidate != routine {
+					ambiguous = true
+				}
+				candidate, candidateHook = routine, hook
+			}
+		}
+	}
+	if candidate == nil || ambiguous {
+		return "", nil, false
+	}
+	return candidateHook, candidate, true
+}
+
+// routineNames returns the lowercased names a routine registered under hook
+// answers to, its hook first.
+func routineNames(hook string, routine *Routine) []string {
+	all := []string{strings.ToLower(commandName(hook))}
+	for _, alias := range routine.Aliases {
+		all = append(all, strings.ToLower(commandName(alias)))
+	}
+	return all
+}
+
+// suggest returns the name in registry closest to name by edit distance,
+// or "" if none is within maxSuggestionDistance.
+// Ties are broken alphabetically so suggestions are stable.
+func suggest(registry RoutineRegistry, name string) string {
+	name = strings.ToLower(commandName(name))
+
+	var candidates []string
+	for hook, routine := range registry {
+		candidates = append(candidates, routineNames(hook, routine)...)
+	}
+	sort.Strings(candidates)
+
+	best, bestDistance := "", maxSuggestionDistance+1
+	for _, candidate := range candidates {
+		distance := editDistance(name, candidate)
+		if distance < bestDistance && distance < len(candidate) {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,130 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+)
+
+// ChatJoinRequestHandler decides on a request to join a chat.
+// Returning true approves the request and false declines it.
+// Returning an error leaves the request pending for the chat's admins.
+type ChatJoinRequestHandler func(ctx context.Context, request *api.ChatJoinRequest) (bool, error)
+
+// ChatMemberHandler is called when the status of a chat member changes.
+type ChatMemberHandler func(ctx context.Context, update *api.ChatMemberUpdated) error
+
+// memberUpdates are the update types Telegram sends when getUpdates names
+// none, plus chat_member, which it only sends when asked for.
+var memberUpdates = []string{
+	"message", "edited_message", "channel_post", "edited_channel_post",
+	"inline_query", "chosen_inline_result", "callback_query",
+	"shipping_query", "pre_checkout_query", "poll", "poll_answer",
+	"my_chat_member", "chat_member", "chat_join_request",
+	"chat_boost", "removed_chat_boost",
+}
+
+// HandleChatJoinRequest registers the handler for chat join requests.
+// The bot must be an administrator with CanInviteUsers to receive them.
+// The handler's decision is sent with ApproveChatJoinRequest or DeclineChatJoinRequest.
+func (bot *TgramBot) HandleChatJoinRequest(handler ChatJoinRequestHandler) {
+	bot.handlers.chatJoinRequest = handler
+}
+
+// HandleChatMember registers the handler for status changes of other
+// chat members. The bot must be an administrator of the chat to receive
+// them. Telegram leaves them out by default, so once a handler is
+// registered GetUpdates asks for them explicitly.
+func (bot *TgramBot) HandleChatMember(handler ChatMemberHandler) {
+	bot.handlers.chatMember = handler
+}
+
+// HandleMyChatMember registers the handler for status changes of the bot
+// itself, e.g. when it is added to a group, promoted or blocked by a user.
+func (bot *TgramBot) HandleMyChatMember(handler ChatMemberHandler) {
+	bot.handlers.myChatMember = handler
+}
+
+// allowedUpdates returns the update types GetUpdates asks for,
+// or nil for Telegram's default set.
+func (bot *TgramBot) allowedUpdates() []string {
+	if bot.handlers.chatMember == nil {
+		return nil
+	}
+	return memberUpdates
+}
+
+// GetChatMember returns the membership of a user in a chat,
+// one of the api.ChatMember variants.
+func (bot *TgramBot) GetChatMember(ctx context.Context, chatID, userID int64) (api.ChatMember, error) {
+	var raw json.RawMessage
+	params := api.GetChatMemberParams{ChatID: chatID, UserID: userID}
+	if err := bot.Call(ctx, "getChatMember", params, &raw); err != nil {
+		return nil, err
+	}
+	return api.UnmarshalChatMember(raw)
+}
+
+// GetChatAdministrators returns the administrators of a chat other than bots.
+func (bot *TgramBot) GetChatAdministrators(ctx context.Context, chatID int64) ([]api.ChatMember, error) {
+	var raws []json.RawMessage
+	if err := bot.Call(ctx, "getChatAdministrators", api.ChatParams{ChatID: chatID}, &raws); err != nil {
+		return nil, err
+	}
+
+	admins := make([]api.ChatMember, len(raws))
+	for i, raw := range raws {
+		admin, err := api.UnmarshalChatMember(raw)
+		if err != nil {
+			return nil, err
+		}
+		admins[i] = admin
+	}
+	return admins, nil
+}
+
+// BanChatMember bans a user from a group, supergroup or channel.
+// In supergroups and channels the user cannot rejoin until unbanned,
+// or until UntilDate if it is set.
+func (bot *TgramBot) BanChatMember(ctx context.Context, params api.BanChatMemberParams) error {
+	return bot.Call(ctx, "banChatMember", params, nil)
+}
+
+// UnbanChatMember lifts a ban, letting the user rejoin through a link.
+// Unless OnlyIfBanned is set, a current member is removed from the chat.
+func (bot *TgramBot) UnbanChatMember(ctx context.Context, params api.UnbanChatMemberParams) error {
+	return bot.Call(ctx, "unbanChatMember", params, nil)
+}
+
+// RestrictChatMember sets the permissions of a user in a supergroup.
+// Passing all permissions lifts the restrictions.
+func (bot *TgramBot) RestrictChatMember(ctx context.Context, params api.RestrictChatMemberParams) error {
+	return bot.Call(ctx, "restrictChatMember", params, nil)
+}
+
+// PromoteChatMember sets the administrator rights of a user.
+// Passing no rights demotes the user.
+func (bot *TgramBot) PromoteChatMember(ctx context.Context, params api.PromoteChatMemberParams) error {
+	return bot.Call(ctx, "promoteChatMember", params, nil)
+}
+
+// ApproveChatJoinRequest lets a user who asked to join into the chat.
+func (bot *TgramBot) ApproveChatJoinRequest(ctx context.Context, chatID, userID int64) error {
+	return bot.Call(ctx, "approveChatJoinRequest", api.ChatMemberParams{ChatID: chatID, UserID: userID}, nil)
+}
+
+// DeclineChatJoinRequest turns down a user's request to join the chat.
+func (bot *TgramBot) DeclineChatJoinRequest(ctx context.Context, chatID, userID int64) error {
+	return bot.Call(ctx, "declineChatJoinRequest", api.ChatMemberParams{ChatID: chatID, UserID: userID}, nil)
+}
+
+func (bot *TgramBot) handleJoinRequest(ctx context.Context, request *api.ChatJoinRequest) error {
+	approve, err := bot.handlers.chatJoinRequest(ctx, request)
+	if err != nil {
+		return err
+	}
+	if approve {
+		return bot.ApproveChatJoinRequest(ctx, request.Chat.Id, request.From.Id)
+	}
+	return bot.DeclineChatJoinRequest(ctx, request.Chat.Id, request.From.Id)
+}
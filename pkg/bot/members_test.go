@@ -0,0 +1,137 @@
+package bot_test
+
+import (
+	"context"
+	"errors"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bot"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bottest"
+	"testing"
+	"time"
+)
+
+func TestChatJoinRequestDecision(t *testing.T) {
+	tests := []struct {
+		name    string
+		approve bool
+		method  string
+		other   string
+	}{
+		{"approve", true, "approveChatJoinRequest", "declineChatJoinRequest"},
+		{"decline", false, "declineChatJoinRequest", "approveChatJoinRequest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := bottest.NewServer()
+			defer s.Close()
+			tgBot := s.Bot(bot.WithAck(bot.AckConfig{Mode: bot.AckNone}))
+			tgBot.HandleChatJoinRequest(func(context.Context, *api.ChatJoinRequest) (bool, error) {
+				return tt.approve, nil
+			})
+
+			s.PushUpdate(api.Update{ChatJoinRequest: &api.ChatJoinRequest{
+				Chat: api.Chat{Id: -100, Type: "supergroup"},
+				From: api.User{Id: 7},
+			}})
+			go tgBot.Run()
+
+			calls := s.WaitForCall(tt.method, 1, 5*time.Second)
+			if len(calls) == 0 {
+				t.Fatalf("%s was not called", tt.method)
+			}
+			if chatID, userID := calls[0].Int("chat_id"), calls[0].Int("user_id"); chatID != -100 || userID != 7 {
+				t.Errorf("%s chat_id = %d, user_id = %d, want -100 and 7", tt.method, chatID, userID)
+			}
+			s.AssertNotCalled(t, tt.other)
+		})
+	}
+}
+
+func TestChatJoinRequestErrorLeavesRequestPending(t *testing.T) {
+	s := bottest.NewServer()
+	defer s.Close()
+	tgBot := s.Bot(bot.WithAck(bot.AckConfig{Mode: bot.AckNone}))
+	handled := make(chan struct{})
+	tgBot.HandleChatJoinRequest(func(context.Context, *api.ChatJoinRequest) (bool, error) {
+		defer close(handled)
+		return false, errors.New("captcha service unavailable")
+	})
+
+	s.PushUpdate(api.Update{ChatJoinRequest: &api.ChatJoinRequest{Chat: api.Chat{Id: -100}, From: api.User{Id: 7}}})
+	go tgBot.Run()
+
+	select {
+	case <-handled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("join request handler was not called")
+	}
+	time.Sleep(50 * time.Millisecond)
+	s.AssertNotCalled(t, "approveChatJoinRequest")
+	s.AssertNotCalled(t, "declineChatJoinRequest")
+}
+
+func TestChatMemberUpdates(t *testing.T) {
+	s := bottest.NewServer()
+	defer s.Close()
+	tgBot := s.Bot(bot.WithAck(bot.AckConfig{Mode: bot.AckNone}))
+
+	members := make(chan *api.ChatMemberUpdated, 1)
+	mine := make(chan *api.ChatMemberUpdated, 1)
+	tgBot.HandleChatMember(func(_ context.Context, update *api.ChatMemberUpdated) error {
+		members <- update
+		return nil
+	})
+	tgBot.HandleMyChatMember(func(_ context.Context, update *api.ChatMemberUpdated) error {
+		mine <- update
+		return nil
+	})
+
+	s.PushUpdate(api.Update{ChatMember: &api.ChatMemberUpdated{
+		Chat:          api.Chat{Id: -100},
+		From:          api.User{Id: 1},
+		OldChatMember: &api.ChatMemberLeft{User: api.User{Id: 7}},
+		NewChatMember: &api.ChatMemberMember{User: api.User{Id: 7}},
+	}})
+	s.PushUpdate(api.Update{MyChatMember: &api.ChatMemberUpdated{
+		Chat:          api.Chat{Id: -200},
+		From:          api.User{Id: 1},
+		OldChatMember: &api.ChatMemberMember{User: s.Me},
+		NewChatMember: &api.ChatMemberAdministrator{User: s.Me},
+	}})
+	go tgBot.Run()
+
+	select {
+	case update := <-members:
+		if update.Chat.Id != -100 || update.NewChatMember.MemberStatus() != "member" {
+			t.Errorf("chat member update = %+v, want user 7 joining chat -100", update)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("chat_member update was not dispatched")
+	}
+	select {
+	case update := <-mine:
+		if update.Chat.Id != -200 || update.NewChatMember.MemberStatus() != "administrator" {
+			t.Errorf("my chat member update = %+v, want the bot promoted in chat -200", update)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("my_chat_member update was not dispatched")
+	}
+
+	var allowed []string
+	if err := s.Calls("getUpdates")[0].Decode("allowed_updates", &allowed); err != nil {
+		t.Fatalf("getUpdates did not ask for chat_member updates: %v", err)
+	}
+	if !contains(allowed, "chat_member") || !contains(allowed, "message") {
+		t.Errorf("allowed_updates = %v, want the defaults plus chat_member", allowed)
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
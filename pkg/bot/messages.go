@@ -0,0 +1,85 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+)
+
+// SendMessage sends a text message described by params.
+// It returns the sent api.Message.
+func (bot *TgramBot) SendMessage(ctx context.Context, params api.SendMessageParams) (*api.Message, error) {
+	return bot.callMessage(ctx, "sendMessage", params)
+}
+
+// ForwardMessage forwards a message of any kind to another chat.
+// It returns the forwarded api.Message.
+func (bot *TgramBot) ForwardMessage(ctx context.Context, params api.ForwardMessageParams) (*api.Message, error) {
+	return bot.callMessage(ctx, "forwardMessage", params)
+}
+
+// CopyMessage copies a message without a link to the original.
+// It returns the ID of the new message.
+func (bot *TgramBot) CopyMessage(ctx context.Context, params api.CopyMessageParams) (*api.MessageId, error) {
+	id := &api.MessageId{}
+	if err := bot.Call(ctx, "copyMessage", params, id); err != nil {
+		return nil, err
+	}
+	return id, nil
+}
+
+// SendLocation sends a point on the map, optionally as a live location.
+// It returns the sent api.Message.
+func (bot *TgramBot) SendLocation(ctx context.Context, params api.SendLocationParams) (*api.Message, error) {
+	return bot.callMessage(ctx, "sendLocation", params)
+}
+
+// SendVenue sends information about a venue.
+// It returns the sent api.Message.
+func (bot *TgramBot) SendVenue(ctx context.Context, params api.SendVenueParams) (*api.Message, error) {
+	return bot.callMessage(ctx, "sendVenue", params)
+}
+
+// SendContact sends a phone contact.
+// It returns the sent api.Message.
+func (bot *TgramBot) SendContact(ctx context.Context, params api.SendContactParams) (*api.Message, error) {
+	return bot.callMessage(ctx, "sendContact", params)
+}
+
+// SendPoll sends a native poll or quiz.
+// It returns the sent api.Message, whose Poll field holds the new poll.
+func (bot *TgramBot) SendPoll(ctx context.Context, params api.SendPollParams) (*api.Message, error) {
+	return bot.callMessage(ctx, "sendPoll", params)
+}
+
+// SendQuiz sends a quiz, a poll with a single correct answer.
+// It accepts the poll params and the index of the correct option.
+// It returns the sent api.Message.
+func (bot *TgramBot) SendQuiz(ctx context.Context, params api.SendPollParams, correctOption int) (*api.Message, error) {
+	if correctOption < 0 || correctOption >= len(params.Options) {
+		return nil, fmt.Errorf("correct option %d out of range for %d options", correctOption, len(params.Options))
+	}
+	params.Type = api.PollTypeQuiz
+	params.CorrectOptionID = &correctOption
+	return bot.SendPoll(ctx, params)
+}
+
+// SendDice sends an animated emoji that displays a random value.
+// It returns the sent api.Message, whose Dice field holds the value.
+func (bot *TgramBot) SendDice(ctx context.Context, params api.SendDiceParams) (*api.Message, error) {
+	return bot.callMessage(ctx, "sendDice", params)
+}
+
+// SendChatAction shows a status such as "typing" in the chat
+// for up to 5 seconds or until the bot's next message arrives.
+func (bot *TgramBot) SendChatAction(ctx context.Context, params api.SendChatActionParams) error {
+	return bot.Call(ctx, "sendChatAction", params, nil)
+}
+
+func (bot *TgramBot) callMessage(ctx context.Context, method string, params interface{}) (*api.Message, error) {
+	msg := &api.Message{}
+	if err := bot.Call(ctx, method, params, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
@@ -0,0 +1,125 @@
+package bot
+
+import (
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/metrics"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// botMetrics are the runtime metrics of a bot created with WithMetrics.
+// Its methods do nothing on a nil *botMetrics, so call sites need no checks.
+type botMetrics struct {
+	updates         *metrics.Counter
+	invocations     *metrics.Counter
+	routineErrors   *metrics.Counter
+	routineDuration *metrics.Histogram
+	throttled       *metrics.Counter
+	apiCalls        *metrics.Counter
+	apiDuration     *metrics.Histogram
+	rateLimited     *metrics.Counter
+	retries         *metrics.Counter
+	pollDuration    *metrics.Histogram
+	queueDepth      *metrics.Gauge
+}
+
+func newBotMetrics(reg *metrics.Registry) *botMetrics {
+	return &botMetrics{
+		updates: reg.Counter("tgram_updates_total",
+			"Updates received, by update type.", "type"),
+		invocations: reg.Counter("tgram_routine_invocations_total",
+			"Routine invocations, by routine.", "routine"),
+		routineErrors: reg.Counter("tgram_routine_errors_total",
+			"Routine invocations that returned an error, by routine.", "routine"),
+		routineDuration: reg.Histogram("tgram_routine_duration_seconds",
+			"Time spent executing routines, by routine.", nil, "routine"),
+		throttled: reg.Counter("tgram_throttled_total",
+			"Routine invocations refused by a Throttle, by routine; empty for unknown commands.", "routine"),
+		apiCalls: reg.Counter("tgram_api_calls_total",
+			"Bot API calls, by method and status: ok, an error code, or error for transport failures.", "method", "status"),
+		apiDuration: reg.Histogram("tgram_api_call_duration_seconds",
+			"Bot API call latency, by method.", nil, "method"),
+		rateLimited: reg.Counter("tgram_api_rate_limited_total",
+			"Bot API calls rejected with 429 Too Many Requests, by method.", "method"),
+		retries: reg.Counter("tgram_broadcast_retries_total",
+			"Broadcast sends retried, by reason: rate_limited after a 429 or transient.", "reason"),
+		pollDuration: reg.Histogram("tgram_poll_duration_seconds",
+			"Time spent in each getUpdates poll.", nil),
+		queueDepth: reg.Gauge("tgram_queue_depth",
+			"Items waiting in Run's internal queues, by queue.", "queue"),
+	}
+}
+
+func (m *botMetrics) update(update api.Update) {
+	if m != nil {
+		m.updates.Inc(updateType(update))
+	}
+}
+
+func (m *botMetrics) routine(command string, duration time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	m.invocations.Inc(command)
+	m.routineDuration.Observe(duration.Seconds(), command)
+	if err != nil {
+		m.routineErrors.Inc(command)
+	}
+}
+
+func (m *botMetrics) throttle(command string) {
+	if m != nil {
+		m.throttled.Inc(command)
+	}
+}
+
+func (m *botMetrics) apiCall(method string, duration time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	status := "ok"
+	if apiErr, ok := api.AsError(err); ok {
+		status = strconv.Itoa(apiErr.Code)
+		if apiErr.Code == http.StatusTooManyRequests {
+			m.rateLimited.Inc(method)
+		}
+	} else if err != nil {
+		status = "error"
+	}
+	m.apiCalls.Inc(method, status)
+	m.apiDuration.Observe(duration.Seconds(), method)
+}
+
+func (m *botMetrics) retry(reason string) {
+	if m != nil {
+		m.retries.Inc(reason)
+	}
+}
+
+func (m *botMetrics) poll(duration time.Duration) {
+	if m != nil {
+		m.pollDuration.Observe(duration.Seconds())
+	}
+}
+
+func (m *botMetrics) queue(name string, depth int) {
+	if m != nil {
+		m.queueDepth.Set(float64(depth), name)
+	}
+}
+
+// updateType returns the JSON name of the update's payload, e.g. "message".
+func updateType(update api.Update) string {
+	v := reflect.ValueOf(update)
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() == reflect.Ptr && !field.IsNil() {
+			name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("json"), ",")
+			return name
+		}
+	}
+	return "unknown"
+}
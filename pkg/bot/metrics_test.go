@@ -0,0 +1,39 @@
+package bot_test
+
+import (
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bot"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bottest"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/metrics"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRoutineMetricsUseRegisteredHooks(t *testing.T) {
+	s := bottest.NewServer()
+	defer s.Close()
+	reg := metrics.NewRegistry()
+	tgBot := s.Bot(bot.WithAck(bot.AckConfig{Mode: bot.AckNone}), bot.WithMetrics(reg), bot.WithPrefixMatching())
+
+	echo := echoRoutine()
+	echo.Aliases = []string{"say"}
+	if err := tgBot.RegisterRoutine("echo", echo); err != nil {
+		t.Fatal(err)
+	}
+
+	s.PushMessage(1, "/say a")
+	s.PushMessage(1, "/ECH b")
+	s.PushMessage(1, "/bogus c")
+	go tgBot.Run()
+	s.WaitForCall("sendMessage", 3, 5*time.Second)
+
+	export := reg.Export()
+	if !strings.Contains(export, `tgram_routine_invocations_total{routine="echo"} 2`) {
+		t.Errorf("invocations are not counted under the registered hook:\n%s", export)
+	}
+	for _, name := range []string{"say", "ECH", "bogus"} {
+		if strings.Contains(export, `routine="/`+name) || strings.Contains(export, `routine="`+name) {
+			t.Errorf("metrics use the typed name %q as a routine label:\n%s", name, export)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package bot
+
+import (
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/metrics"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Option configures a TgramBot during construction.
+// Options are passed to NewTgramBot and applied in order.
+type Option func(*TgramBot)
+
+// WithBaseURL points the bot at a different Bot API server.
+// It accepts the server's base URL without the /bot<token> suffix,
+// e.g. "http://localhost:8081".
+func WithBaseURL(baseURL string) Option {
+	return func(bot *TgramBot) {
+		bot.baseURL = baseURL
+	}
+}
+
+// WithHTTPClient replaces the HTTP client used for API requests.
+// It is useful for setting timeouts or pointing the bot at a test server.
+func WithHTTPClient(client *http.Client) Option {
+	return func(bot *TgramBot) {
+		bot.client = client
+	}
+}
+
+// WithTransport replaces the RoundTripper of the bot's HTTP client.
+// The client is copied first, so a client passed to WithHTTPClient is not modified.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(bot *TgramBot) {
+		client := *bot.client
+		client.Transport = transport
+		bot.client = &client
+	}
+}
+
+// WithProxy routes all API requests through the given proxy.
+// It accepts any proxy URL supported by net/http, e.g. "http://proxy:3128"
+// or "socks5://localhost:1080".
+func WithProxy(proxyURL *url.URL) Option {
+	return func(bot *TgramBot) {
+		transport, ok := bot.client.Transport.(*http.Transport)
+		if !ok || transport == nil {
+			transport = http.DefaultTransport.(*http.Transport)
+		}
+		transport = transport.Clone()
+		transport.Proxy = http.ProxyURL(proxyURL)
+		WithTransport(transport)(bot)
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every API request.
+func WithUserAgent(userAgent string) Option {
+	return func(bot *TgramBot) {
+		bot.userAgent = userAgent
+	}
+}
+
+// WithTestEnvironment sends requests to Telegram's test environment,
+// i.e. /bot<token>/test/<method>. Test environment bots need a token
+// issued by the test environment's BotFather.
+func WithTestEnvironment() Option {
+	return func(bot *TgramBot) {
+		bot.testEnv = true
+	}
+}
+
+// WithLocalServer points the bot at a self-hosted telegram-bot-api server
+// running with --local. In local mode files on disk are passed by path
+// instead of being uploaded, downloaded files are read straight from disk,
+// and the larger api.LocalMaxUploadSize limit applies.
+func WithLocalServer(baseURL string) Option {
+	return func(bot *TgramBot) {
+		bot.baseURL = baseURL
+		bot.local = true
+	}
+}
+
+// WithAdminCacheTTL sets how long the bot remembers whether a user is a
+// chat administrator when enforcing AccessPolicy.AdminOnly. Shorter TTLs
+// notice demotions sooner at the cost of more getChatMember calls.
+func WithAdminCacheTTL(ttl time.Duration) Option {
+	return func(bot *TgramBot) {
+		bot.admins = newAdminCache(ttl)
+	}
+}
+
+// WithAccessAudit replaces the function told about every invocation
+// rejected by an AccessPolicy. By default denials are logged.
+func WithAccessAudit(audit func(AccessDenial)) Option {
+	return func(bot *TgramBot) {
+		bot.audit = audit
+	}
+}
+
+// WithThrottle rate limits invocations of every routine with throttle.
+// Routines may add a stricter limit of their own with Routine.Throttle.
+func WithThrottle(throttle *Throttle) Option {
+	return func(bot *TgramBot) {
+		bot.throttle = throttle
+	}
+}
+
+// WithCommandMenu makes Run publish the registered routines as Telegram's
+// command menu at startup, for each of the given scopes, or for the default
+// scope if none are given. See PublishCommands.
+func WithCommandMenu(scopes ...api.BotCommandScope) Option {
+	return func(bot *TgramBot) {
+		bot.publishCommands = true
+		bot.commandScopes = scopes
+	}
+}
+
+// WithPrefixMatching lets users invoke routines and subcommands by any
+// prefix that matches only one of them, e.g. "/ec" for "/echo".
+func WithPrefixMatching() Option {
+	return func(bot *TgramBot) {
+		bot.prefixMatching = true
+	}
+}
+
+// WithUnknownCommandReply replaces the reply sent when a message does not
+// invoke a registered routine. reply receives the unknown command and the
+// closest registered one, or "" if none is close; returning "" sends nothing.
+// Passing nil silences unknown commands entirely.
+func WithUnknownCommandReply(reply func(command, suggestion string) string) Option {
+	return func(bot *TgramBot) {
+		if reply == nil {
+			reply = func(string, string) string { return "" }
+		}
+		bot.unknownReply = reply
+	}
+}
+
+// WithAck configures how Run acknowledges incoming messages.
+// By default every message is answered with DefaultAckTemplate.
+func WithAck(config AckConfig) Option {
+	return func(bot *TgramBot) {
+		bot.ack = config
+	}
+}
+
+// WithLogger sets the logger the bot reports errors, denied and throttled
+// invocations and routine timings to. By default the bot logs nothing;
+// passing nil restores that.
+func WithLogger(logger *slog.Logger) Option {
+	return func(bot *TgramBot) {
+		if logger == nil {
+			logger = slog.New(discardHandler{})
+		}
+		bot.logger = logger
+	}
+}
+
+// WithTracer records spans around each received update, its handler,
+// the parsing, authorization and execution of routines, and every Bot API
+// call. The context passed to routines and handlers carries the active span.
+// By default nothing is traced; passing nil restores that.
+func WithTracer(tracer Tracer) Option {
+	return func(bot *TgramBot) {
+		if tracer == nil {
+			tracer = noopTracer{}
+		}
+		bot.tracer = tracer
+	}
+}
+
+// WithMetrics records the bot's runtime metrics in reg: updates by type,
+// routine invocations, errors and latency by registered hook, API calls by
+// method and status, 429 responses, broadcast retries, throttled
+// invocations, getUpdates latency and queue depth.
+// Serve reg over HTTP to let Prometheus scrape them.
+func WithMetrics(reg *metrics.Registry) Option {
+	return func(bot *TgramBot) {
+		bot.metrics = newBotMetrics(reg)
+	}
+}
@@ -0,0 +1,25 @@
+package bot_test
+
+import (
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bot"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bottest"
+	"testing"
+	"time"
+)
+
+func TestNilOptionsRestoreDefaults(t *testing.T) {
+	s := bottest.NewServer()
+	defer s.Close()
+	tgBot := s.Bot(bot.WithAck(bot.AckConfig{Mode: bot.AckNone}), bot.WithLogger(nil), bot.WithTracer(nil))
+	if err := tgBot.RegisterRoutine("echo", echoRoutine()); err != nil {
+		t.Fatal(err)
+	}
+
+	s.PushMessage(1, "/echo hi")
+	s.PushMessage(1, "/ehco hi")
+	go tgBot.Run()
+
+	s.WaitForCall("sendMessage", 2, 5*time.Second)
+	s.AssertSent(t, 1, "hi")
+	s.AssertSent(t, 1, `Unknown command "/ehco". Did you mean /echo?`)
+}
@@ -0,0 +1,13 @@
+package bot
+
+import (
+	"context"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+)
+
+// SetPassportDataErrors tells a user that some of the Telegram Passport
+// elements they shared contain errors. The user will not be able to
+// resend an element until the errors are fixed.
+func (bot *TgramBot) SetPassportDataErrors(ctx context.Context, params api.SetPassportDataErrorsParams) error {
+	return bot.Call(ctx, "setPassportDataErrors", params, nil)
+}
@@ -0,0 +1,169 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+	"time"
+)
+
+// paymentAnswerTimeout is how long payment handlers may run.
+// Telegram cancels shipping and pre-checkout queries that are not
+// answered within 10 seconds, so this leaves time to send the answer.
+const paymentAnswerTimeout = 8 * time.Second
+
+// DefaultPaymentErrorMessage is shown to the user when a payment handler
+// fails with an error other than a *PaymentError.
+const DefaultPaymentErrorMessage = "Sorry, your order could not be processed. Please try again later."
+
+// PaymentError declines a shipping or pre-checkout query with a message
+// meant for the user, e.g. "We don't deliver to your country".
+// Handlers return it to tell the user why; any other error is logged
+// and the user sees DefaultPaymentErrorMessage instead.
+type PaymentError struct {
+	Message string
+}
+
+func (e *PaymentError) Error() string {
+	return e.Message
+}
+
+// ShippingQueryHandler returns the shipping options available for a query
+// from an invoice with IsFlexible set. Returning an error, or no options,
+// declines the query. See PaymentError for what the user is shown.
+type ShippingQueryHandler func(ctx context.Context, query *api.ShippingQuery) ([]api.ShippingOption, error)
+
+// PreCheckoutQueryHandler confirms an order just before the payment is made.
+// Returning nil approves the payment. Returning an error declines it.
+// See PaymentError for what the user is shown.
+type PreCheckoutQueryHandler func(ctx context.Context, query *api.PreCheckoutQuery) error
+
+// SuccessfulPaymentHandler is called with the service message
+// Telegram sends after a payment went through.
+type SuccessfulPaymentHandler func(ctx context.Context, msg *api.Message) error
+
+// HandleShippingQuery registers the handler for shipping queries.
+// The handler's result is sent with AnswerShippingQuery. If it does not
+// return in time the query is declined, since Telegram only waits 10 seconds.
+func (bot *TgramBot) HandleShippingQuery(handler ShippingQueryHandler) {
+	bot.handlers.shippingQuery = handler
+}
+
+// HandlePreCheckoutQuery registers the handler for pre-checkout queries.
+// The handler's result is sent with AnswerPreCheckoutQuery. If it does not
+// return in time the payment is declined, since Telegram only waits 10 seconds.
+func (bot *TgramBot) HandlePreCheckoutQuery(handler PreCheckoutQueryHandler) {
+	bot.handlers.preCheckoutQuery = handler
+}
+
+// HandleSuccessfulPayment registers the handler for successful payment messages.
+// These messages are not passed to routines.
+func (bot *TgramBot) HandleSuccessfulPayment(handler SuccessfulPaymentHandler) {
+	bot.handlers.successfulPayment = handler
+}
+
+// SendInvoice sends an invoice to a chat.
+// It returns the sent api.Message.
+func (bot *TgramBot) SendInvoice(ctx context.Context, params api.SendInvoiceParams) (*api.Message, error) {
+	return bot.callMessage(ctx, "sendInvoice", params)
+}
+
+// CreateInvoiceLink creates a link for an invoice that can be shared anywhere.
+// It returns the link.
+func (bot *TgramBot) CreateInvoiceLink(ctx context.Context, params api.CreateInvoiceLinkParams) (string, error) {
+	var link string
+	if err := bot.Call(ctx, "createInvoiceLink", params, &link); err != nil {
+		return "", err
+	}
+	return link, nil
+}
+
+// AnswerShippingQuery replies to a shipping query with the available
+// shipping options, or with an error message if delivery is impossible.
+func (bot *TgramBot) AnswerShippingQuery(ctx context.Context, params api.AnswerShippingQueryParams) error {
+	return bot.Call(ctx, "answerShippingQuery", params, nil)
+}
+
+// AnswerPreCheckoutQuery confirms or declines a pre-checkout query.
+func (bot *TgramBot) AnswerPreCheckoutQuery(ctx context.Context, params api.AnswerPreCheckoutQueryParams) error {
+	return bot.Call(ctx, "answerPreCheckoutQuery", params, nil)
+}
+
+func (bot *TgramBot) answerShipping(ctx context.Context, query *api.ShippingQuery) error {
+	var options []api.ShippingOption
+	err := withDeadline(ctx, paymentAnswerTimeout, func(ctx context.Context) error {
+		var err error
+		options, err = bot.handlers.shippingQuery(ctx, query)
+		return err
+	})
+	if err == nil && len(options) == 0 {
+		err = errors.New("shipping query handler returned no options")
+	}
+
+	params := api.AnswerShippingQueryParams{ShippingQueryID: query.ID, Ok: err == nil}
+	if err != nil {
+		params.ErrorMessage = paymentErrorMessage(err)
+	} else {
+		params.ShippingOptions = options
+	}
+	if answerErr := bot.AnswerShippingQuery(ctx, params); answerErr != nil {
+		return answerErr
+	}
+	return unexpectedPaymentError(err)
+}
+
+func (bot *TgramBot) answerPreCheckout(ctx context.Context, query *api.PreCheckoutQuery) error {
+	err := withDeadline(ctx, paymentAnswerTimeout, func(ctx context.Context) error {
+		return bot.handlers.preCheckoutQuery(ctx, query)
+	})
+
+	params := api.AnswerPreCheckoutQueryParams{PreCheckoutQueryID: query.ID, Ok: err == nil}
+	if err != nil {
+		params.ErrorMessage = paymentErrorMessage(err)
+	}
+	if answerErr := bot.AnswerPreCheckoutQuery(ctx, params); answerErr != nil {
+		return answerErr
+	}
+	return unexpectedPaymentError(err)
+}
+
+// paymentErrorMessage returns the message shown to a user whose query
+// a handler declined with err.
+func paymentErrorMessage(err error) string {
+	var paymentErr *PaymentError
+	if errors.As(err, &paymentErr) && paymentErr.Message != "" {
+		return paymentErr.Message
+	}
+	return DefaultPaymentErrorMessage
+}
+
+// unexpectedPaymentError returns err unless it is a *PaymentError,
+// so that handler failures are logged but deliberate declines are not.
+func unexpectedPaymentError(err error) error {
+	var paymentErr *PaymentError
+	if errors.As(err, &paymentErr) {
+		return nil
+	}
+	return err
+}
+
+// withDeadline runs fn with a context that expires after timeout.
+// It returns fn's error, or an error as soon as the deadline passes
+// even if fn has not returned yet.
+func withDeadline(ctx context.Context, timeout time.Duration, fn func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- fn(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("payment handler did not return within %s: %w", timeout, ctx.Err())
+	}
+}
@@ -0,0 +1,78 @@
+package bot_test
+
+import (
+	"context"
+	"errors"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bot"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bottest"
+	"testing"
+	"time"
+)
+
+func pushShippingQuery(s *bottest.Server, id string) {
+	s.PushUpdate(api.Update{ShippingQuery: &api.ShippingQuery{
+		ID:   id,
+		From: &api.User{Id: 1},
+	}})
+}
+
+func TestShippingQueryAnswers(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []api.ShippingOption
+		err     error
+		ok      bool
+		message string
+	}{
+		{"options", []api.ShippingOption{{ID: "post", Title: "Post"}}, nil, true, ""},
+		{"no options", nil, nil, false, bot.DefaultPaymentErrorMessage},
+		{"payment error", nil, &bot.PaymentError{Message: "We don't ship there"}, false, "We don't ship there"},
+		{"internal error", nil, errors.New("db: connection refused"), false, bot.DefaultPaymentErrorMessage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := bottest.NewServer()
+			defer s.Close()
+			tgBot := s.Bot()
+			tgBot.HandleShippingQuery(func(context.Context, *api.ShippingQuery) ([]api.ShippingOption, error) {
+				return tt.options, tt.err
+			})
+
+			pushShippingQuery(s, "q")
+			go tgBot.Run()
+			calls := s.WaitForCall("answerShippingQuery", 1, 5*time.Second)
+			if len(calls) == 0 {
+				t.Fatal("shipping query was not answered")
+			}
+
+			call := calls[0]
+			if ok := call.Param("ok") == "true"; ok != tt.ok {
+				t.Errorf("ok = %v, want %v", ok, tt.ok)
+			}
+			if got := call.Param("error_message"); got != tt.message {
+				t.Errorf("error_message = %q, want %q", got, tt.message)
+			}
+		})
+	}
+}
+
+func TestPreCheckoutHidesInternalErrors(t *testing.T) {
+	s := bottest.NewServer()
+	defer s.Close()
+	tgBot := s.Bot()
+	tgBot.HandlePreCheckoutQuery(func(context.Context, *api.PreCheckoutQuery) error {
+		return errors.New("stock service: 500 internal server error")
+	})
+
+	s.PushUpdate(api.Update{PreCheckoutQuery: &api.PreCheckoutQuery{ID: "p", From: &api.User{Id: 1}}})
+	go tgBot.Run()
+	calls := s.WaitForCall("answerPreCheckoutQuery", 1, 5*time.Second)
+	if len(calls) == 0 {
+		t.Fatal("pre-checkout query was not answered")
+	}
+	if got := calls[0].Param("error_message"); got != bot.DefaultPaymentErrorMessage {
+		t.Errorf("error_message = %q, want the default message", got)
+	}
+}
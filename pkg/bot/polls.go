@@ -0,0 +1,258 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// PollHandler is called when the state of a poll changes.
+// Telegram only sends these for stopped polls and polls sent by the bot.
+type PollHandler func(ctx context.Context, poll *api.Poll) error
+
+// PollAnswerHandler is called when a user changes their answer
+// in a non-anonymous poll sent by the bot. Votes cast on behalf of a
+// chat carry VoterChat instead of User. An empty OptionIDs means the
+// voter retracted their vote.
+type PollAnswerHandler func(ctx context.Context, answer *api.PollAnswer) error
+
+// HandlePoll registers the handler for poll state updates.
+func (bot *TgramBot) HandlePoll(handler PollHandler) {
+	bot.handlers.poll = handler
+}
+
+// HandlePollAnswer registers the handler for poll answers.
+func (bot *TgramBot) HandlePollAnswer(handler PollAnswerHandler) {
+	bot.handlers.pollAnswer = handler
+}
+
+// StopPoll closes a poll sent by the bot.
+// It returns the final api.Poll with its results.
+func (bot *TgramBot) StopPoll(ctx context.Context, params api.StopPollParams) (*api.Poll, error) {
+	poll := &api.Poll{}
+	if err := bot.Call(ctx, "stopPoll", params, poll); err != nil {
+		return nil, err
+	}
+	return poll, nil
+}
+
+// PollResults is the tally of a tracked poll.
+// Counts holds the number of votes for each option, in order.
+// Anonymous polls send no answers, so their tally comes from the
+// voter counts of the latest poll update instead.
+type PollResults struct {
+	Question string
+	Options  []string
+	Counts   []int
+	Voters   int
+	IsClosed bool
+}
+
+type trackedPoll struct {
+	Question    string          `json:"question"`
+	Options     []string        `json:"options"`
+	IsClosed    bool            `json:"is_closed"`
+	Answers     map[int64][]int `json:"answers"`
+	Counts      []int           `json:"counts,omitempty"`
+	TotalVoters int             `json:"total_voters,omitempty"`
+}
+
+// update copies the state of poll, including its voter counts.
+func (tracked *trackedPoll) update(poll *api.Poll) {
+	tracked.Question = poll.Question
+	tracked.IsClosed = poll.IsClosed
+	tracked.TotalVoters = poll.TotalVoterCount
+	tracked.Options = tracked.Options[:0]
+	tracked.Counts = tracked.Counts[:0]
+	for _, option := range poll.Options {
+		tracked.Options = append(tracked.Options, option.Text)
+		tracked.Counts = append(tracked.Counts, option.VoterCount)
+	}
+}
+
+// voterID identifies who cast answer: the voting chat's ID when the
+// vote was cast on behalf of a chat, else the user's ID. Chat IDs are
+// negative, so the two never collide.
+func voterID(answer *api.PollAnswer) (int64, bool) {
+	switch {
+	case answer.VoterChat != nil:
+		return answer.VoterChat.Id, true
+	case answer.User != nil:
+		return answer.User.Id, true
+	}
+	return 0, false
+}
+
+// PollTracker aggregates poll answers per poll and user.
+// Polls are registered with Track after sending, and answers are fed in
+// by passing HandlePollAnswer and HandlePoll to the bot's registration methods.
+// A PollTracker created with a path saves its state there after every change,
+// so results survive restarts. It is safe for concurrent use.
+type PollTracker struct {
+	mu    sync.Mutex
+	path  string
+	polls map[string]*trackedPoll
+}
+
+// NewPollTracker constructs a PollTracker persisted to the JSON file at path,
+// loading any state saved there before. An empty path keeps state in memory.
+func NewPollTracker(path string) (*PollTracker, error) {
+	tracker := &PollTracker{path: path, polls: map[string]*trackedPoll{}}
+	if path == "" {
+		return tracker, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return tracker, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &tracker.polls); err != nil {
+		return nil, err
+	}
+	return tracker, nil
+}
+
+// Track starts aggregating answers to poll, e.g. the Poll of the
+// api.Message returned by SendPoll. Tracking a poll again keeps its answers.
+func (t *PollTracker) Track(poll *api.Poll) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tracked, ok := t.polls[poll.ID]
+	if !ok {
+		tracked = &trackedPoll{Answers: map[int64][]int{}}
+		t.polls[poll.ID] = tracked
+	}
+	tracked.update(poll)
+	return t.save()
+}
+
+// Forget stops tracking a poll and discards its answers.
+func (t *PollTracker) Forget(pollID string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.polls, pollID)
+	return t.save()
+}
+
+// HandlePollAnswer records a voter's answer. It is a PollAnswerHandler.
+// Answers to untracked polls and answers without a voter are ignored.
+func (t *PollTracker) HandlePollAnswer(_ context.Context, answer *api.PollAnswer) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tracked, ok := t.polls[answer.PollID]
+	if !ok {
+		return nil
+	}
+	voter, ok := voterID(answer)
+	if !ok {
+		return nil
+	}
+	if len(answer.OptionIDs) == 0 {
+		delete(tracked.Answers, voter)
+	} else {
+		tracked.Answers[voter] = answer.OptionIDs
+	}
+	return t.save()
+}
+
+// HandlePoll records the state of a tracked poll, including whether it
+// was closed and its voter counts. It is a PollHandler.
+func (t *PollTracker) HandlePoll(_ context.Context, poll *api.Poll) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tracked, ok := t.polls[poll.ID]
+	if !ok {
+		return nil
+	}
+	tracked.update(poll)
+	return t.save()
+}
+
+// Results returns the current tally of a tracked poll.
+// It returns false if the poll is not tracked.
+func (t *PollTracker) Results(pollID string) (PollResults, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tracked, ok := t.polls[pollID]
+	if !ok {
+		return PollResults{}, false
+	}
+
+	results := PollResults{
+		Question: tracked.Question,
+		Options:  append([]string(nil), tracked.Options...),
+		Counts:   make([]int, len(tracked.Options)),
+		Voters:   len(tracked.Answers),
+		IsClosed: tracked.IsClosed,
+	}
+	if len(tracked.Answers) == 0 && len(tracked.Counts) == len(tracked.Options) {
+		copy(results.Counts, tracked.Counts)
+		results.Voters = tracked.TotalVoters
+		return results, true
+	}
+	for _, options := range tracked.Answers {
+		for _, option := range options {
+			if option >= 0 && option < len(results.Counts) {
+				results.Counts[option]++
+			}
+		}
+	}
+	return results, true
+}
+
+// Voters returns the option IDs chosen by each voter who answered a tracked poll,
+// keyed by user ID, or by chat ID for votes cast on behalf of a chat.
+func (t *PollTracker) Voters(pollID string) map[int64][]int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	voters := map[int64][]int{}
+	if tracked, ok := t.polls[pollID]; ok {
+		for userID, options := range tracked.Answers {
+			voters[userID] = append([]int(nil), options...)
+		}
+	}
+	return voters
+}
+
+// save writes the tracker's state to its file, replacing it atomically.
+// It must be called with t.mu held.
+func (t *PollTracker) save() error {
+	if t.path == "" {
+		return nil
+	}
+	return writeJSONFile(t.path, t.polls)
+}
+
+// writeJSONFile writes v as JSON to the file at path, replacing it atomically.
+func writeJSONFile(path string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
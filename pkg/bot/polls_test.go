@@ -0,0 +1,81 @@
+package bot_test
+
+import (
+	"context"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bot"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func testPoll(id string, counts ...int) *api.Poll {
+	poll := &api.Poll{ID: id, Question: "Lunch?"}
+	for i, text := range []string{"Pizza", "Salad"} {
+		poll.Options = append(poll.Options, api.PollOption{Text: text, VoterCount: counts[i]})
+		poll.TotalVoterCount += counts[i]
+	}
+	return poll
+}
+
+func TestPollTrackerKeysAnswersByVoter(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "polls.json")
+	tracker, err := bot.NewPollTracker(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tracker.Track(testPoll("p", 0, 0)); err != nil {
+		t.Fatal(err)
+	}
+
+	answers := []*api.PollAnswer{
+		{PollID: "p", User: &api.User{Id: 1}, OptionIDs: []int{0}},
+		{PollID: "p", VoterChat: &api.Chat{Id: -100}, OptionIDs: []int{1}},
+		{PollID: "p", VoterChat: &api.Chat{Id: -200}, OptionIDs: []int{1}},
+		{PollID: "p", OptionIDs: []int{0}},
+	}
+	for _, answer := range answers {
+		if err := tracker.HandlePollAnswer(ctx, answer); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := map[int64][]int{1: {0}, -100: {1}, -200: {1}}
+	if voters := tracker.Voters("p"); !reflect.DeepEqual(voters, want) {
+		t.Errorf("Voters = %v, want %v", voters, want)
+	}
+
+	reloaded, err := bot.NewPollTracker(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, ok := reloaded.Results("p")
+	if !ok || results.Voters != 3 || !reflect.DeepEqual(results.Counts, []int{1, 2}) {
+		t.Errorf("Results after reload = %+v, want 3 voters counted 1, 2", results)
+	}
+}
+
+func TestPollTrackerAnonymousCounts(t *testing.T) {
+	tracker, err := bot.NewPollTracker("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	anonymous := testPoll("anon", 0, 0)
+	anonymous.IsAnonymous = true
+	if err := tracker.Track(anonymous); err != nil {
+		t.Fatal(err)
+	}
+
+	update := testPoll("anon", 3, 1)
+	update.IsAnonymous = true
+	update.IsClosed = true
+	if err := tracker.HandlePoll(context.Background(), update); err != nil {
+		t.Fatal(err)
+	}
+
+	results, ok := tracker.Results("anon")
+	if !ok || !results.IsClosed || results.Voters != 4 || !reflect.DeepEqual(results.Counts, []int{3, 1}) {
+		t.Errorf("Results = %+v, want a closed poll with 4 voters counted 3, 1", results)
+	}
+}
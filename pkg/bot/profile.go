@@ -0,0 +1,105 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+)
+
+// SetMyCommands changes the list of the bot's commands
+// for the given scope and language.
+func (bot *TgramBot) SetMyCommands(ctx context.Context, params api.SetMyCommandsParams) error {
+	return bot.Call(ctx, "setMyCommands", params, nil)
+}
+
+// DeleteMyCommands deletes the list of the bot's commands
+// for the given scope and language.
+func (bot *TgramBot) DeleteMyCommands(ctx context.Context, params api.MyCommandsParams) error {
+	return bot.Call(ctx, "deleteMyCommands", params, nil)
+}
+
+// GetMyCommands returns the list of the bot's commands
+// for the given scope and language.
+func (bot *TgramBot) GetMyCommands(ctx context.Context, params api.MyCommandsParams) ([]api.BotCommand, error) {
+	var commands []api.BotCommand
+	if err := bot.Call(ctx, "getMyCommands", params, &commands); err != nil {
+		return nil, err
+	}
+	return commands, nil
+}
+
+// SetMyName changes the bot's name for the given language.
+func (bot *TgramBot) SetMyName(ctx context.Context, params api.SetMyNameParams) error {
+	return bot.Call(ctx, "setMyName", params, nil)
+}
+
+// GetMyName returns the bot's name for the given language code.
+func (bot *TgramBot) GetMyName(ctx context.Context, languageCode string) (*api.BotName, error) {
+	name := &api.BotName{}
+	if err := bot.Call(ctx, "getMyName", api.LanguageParams{LanguageCode: languageCode}, name); err != nil {
+		return nil, err
+	}
+	return name, nil
+}
+
+// SetMyDescription changes the description shown in an empty chat with the bot.
+func (bot *TgramBot) SetMyDescription(ctx context.Context, params api.SetMyDescriptionParams) error {
+	return bot.Call(ctx, "setMyDescription", params, nil)
+}
+
+// GetMyDescription returns the bot's description for the given language code.
+func (bot *TgramBot) GetMyDescription(ctx context.Context, languageCode string) (*api.BotDescription, error) {
+	description := &api.BotDescription{}
+	if err := bot.Call(ctx, "getMyDescription", api.LanguageParams{LanguageCode: languageCode}, description); err != nil {
+		return nil, err
+	}
+	return description, nil
+}
+
+// SetMyShortDescription changes the short description shown on the bot's
+// profile page and sent together with links to the bot.
+func (bot *TgramBot) SetMyShortDescription(ctx context.Context, params api.SetMyShortDescriptionParams) error {
+	return bot.Call(ctx, "setMyShortDescription", params, nil)
+}
+
+// GetMyShortDescription returns the bot's short description for the given language code.
+func (bot *TgramBot) GetMyShortDescription(ctx context.Context, languageCode string) (*api.BotShortDescription, error) {
+	description := &api.BotShortDescription{}
+	if err := bot.Call(ctx, "getMyShortDescription", api.LanguageParams{LanguageCode: languageCode}, description); err != nil {
+		return nil, err
+	}
+	return description, nil
+}
+
+// SetChatMenuButton changes the bot's menu button in a private chat,
+// or the default menu button if params.ChatID is 0.
+func (bot *TgramBot) SetChatMenuButton(ctx context.Context, params api.SetChatMenuButtonParams) error {
+	return bot.Call(ctx, "setChatMenuButton", params, nil)
+}
+
+// GetChatMenuButton returns the bot's menu button in a private chat,
+// or the default menu button if chatID is 0.
+func (bot *TgramBot) GetChatMenuButton(ctx context.Context, chatID int64) (api.MenuButton, error) {
+	var raw json.RawMessage
+	if err := bot.Call(ctx, "getChatMenuButton", api.GetChatMenuButtonParams{ChatID: chatID}, &raw); err != nil {
+		return nil, err
+	}
+	return api.UnmarshalMenuButton(raw)
+}
+
+// SetMyDefaultAdministratorRights changes the rights requested
+// when the bot is added to groups or channels as an administrator.
+func (bot *TgramBot) SetMyDefaultAdministratorRights(ctx context.Context, params api.SetMyDefaultAdministratorRightsParams) error {
+	return bot.Call(ctx, "setMyDefaultAdministratorRights", params, nil)
+}
+
+// GetMyDefaultAdministratorRights returns the bot's default administrator rights
+// for groups, or for channels if forChannels is true.
+func (bot *TgramBot) GetMyDefaultAdministratorRights(ctx context.Context, forChannels bool) (*api.ChatAdministratorRights, error) {
+	rights := &api.ChatAdministratorRights{}
+	params := api.GetMyDefaultAdministratorRightsParams{ForChannels: forChannels}
+	if err := bot.Call(ctx, "getMyDefaultAdministratorRights", params, rights); err != nil {
+		return nil, err
+	}
+	return rights, nil
+}
@@ -0,0 +1,344 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Call invokes a Bot API method and decodes its result.
+// It accepts a context.Context, the method name, a params struct and
+// a pointer to decode the result into. Either params or result may be nil.
+// Params are sent as a JSON body, or as multipart form data when a top-level
+// *api.InputFile field needs uploading.
+// It returns an *api.Error if Telegram rejected the request.
+func (bot *TgramBot) Call(ctx context.Context, method string, params interface{}, result interface{}) error {
+	ctx, span := bot.tracer.Start(ctx, SpanAPICall+" "+method, Attr("tgram.method", method))
+	defer span.End()
+
+	start := time.Now()
+	err := bot.call(ctx, method, params, result)
+	bot.metrics.apiCall(method, time.Since(start), err)
+	if apiErr, ok := api.AsError(err); ok {
+		span.SetAttributes(Attr("tgram.error_code", apiErr.Code))
+	}
+	span.RecordError(err)
+	return err
+}
+
+func (bot *TgramBot) call(ctx context.Context, method string, params interface{}, result interface{}) error {
+	req, err := bot.newCallRequest(ctx, method, params)
+	if err != nil {
+		return err
+	}
+
+	resp, err := bot.do(req)
+	if err != nil {
+		return err
+	}
+
+	raw, err := resp.Unwrap()
+	if err != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(raw, result); err != nil {
+		return fmt.Errorf("unable to unmarshal %s result: %w", method, err)
+	}
+
+	return nil
+}
+
+// UploadLimit returns the largest file the bot may upload, in bytes.
+func (bot *TgramBot) UploadLimit() int64 {
+	if bot.local {
+		return api.LocalMaxUploadSize
+	}
+	return api.MaxUploadSize
+}
+
+func (bot *TgramBot) endpoint(resource string) string {
+	if bot.testEnv {
+		return api.MakeTestEndpointStrWithBase(bot.baseURL, resource, bot.key)
+	}
+	return api.MakeEndpointStrWithBase(bot.baseURL, resource, bot.key)
+}
+
+func (bot *TgramBot) do(req *http.Request) (*api.Response, error) {
+	if bot.userAgent != "" {
+		req.Header.Set("User-Agent", bot.userAgent)
+	}
+
+	response, err := bot.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		err := response.Body.Close()
+		if err != nil {
+			bot.logger.Warn("unable to close response body", "error", err)
+		}
+	}()
+
+	respBody := &api.Response{}
+	if err := json.NewDecoder(response.Body).Decode(respBody); err != nil {
+		return nil, err
+	}
+
+	return respBody, nil
+}
+
+func (bot *TgramBot) newCallRequest(ctx context.Context, method string, params interface{}) (*http.Request, error) {
+	reqUrl := bot.endpoint(method)
+	if params == nil {
+		return http.NewRequestWithContext(ctx, "POST", reqUrl, nil)
+	}
+
+	attached, uploads := bot.attachUploads(params)
+	if len(uploads) == 0 {
+		body, err := json.Marshal(params)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal %s params: %w", method, err)
+		}
+		req, err := http.NewRequestWithContext(ctx, "POST", reqUrl, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	}
+
+	fields, err := multipartFields(attached, uploads)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal %s params: %w", method, err)
+	}
+
+	pr, pw := io.Pipe()
+	form := multipart.NewWriter(pw)
+	go func() {
+		pw.CloseWithError(bot.writeMultipart(form, fields, uploads))
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", reqUrl, pr)
+	if err != nil {
+		pr.Close()
+		return nil, err
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	return req, nil
+}
+
+var inputFileType = reflect.TypeOf((*api.InputFile)(nil))
+
+// attachUploads returns the InputFiles in params that must be sent as
+// multipart data, keyed by the name of their part, and a copy of params
+// in which those files are attached under that name.
+// Top-level files are sent in a part named after their parameter.
+// Files nested deeper, e.g. in the InputMedia of a media group,
+// are sent in parts named "upload0", "upload1" and so on.
+// params itself is never modified, so the same InputFile can be
+// passed to concurrent calls.
+func (bot *TgramBot) attachUploads(params interface{}) (interface{}, map[string]*api.InputFile) {
+	v := reflect.Indirect(reflect.ValueOf(params))
+	if v.Kind() != reflect.Struct {
+		return params, nil
+	}
+
+	names := map[*api.InputFile]string{}
+	uploads := map[string]*api.InputFile{}
+	attached := reflect.New(v.Type()).Elem()
+	attached.Set(v)
+	for i := 0; i < v.NumField(); i++ {
+		if !v.Type().Field(i).IsExported() {
+			continue
+		}
+		field := v.Field(i)
+		if field.Type() != inputFileType {
+			if nested, ok := bot.attachNested(field, names, uploads); ok {
+				attached.Field(i).Set(nested)
+			}
+			continue
+		}
+		file, _ := field.Interface().(*api.InputFile)
+		if file == nil || !file.NeedsUpload(bot.local) {
+			continue
+		}
+		name := strings.Split(v.Type().Field(i).Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			name = v.Type().Field(i).Name
+		}
+		names[file] = name
+		uploads[name] = file
+		attached.Field(i).Set(reflect.ValueOf(file.AttachedAs(name)))
+	}
+
+	return attached.Interface(), uploads
+}
+
+// attachNested returns a copy of v in which the InputFiles below it that
+// need uploading are attached, and adds them to uploads.
+// It reports false, and returns v as is, when v holds no such file.
+func (bot *TgramBot) attachNested(v reflect.Value, names map[*api.InputFile]string, uploads map[string]*api.InputFile) (reflect.Value, bool) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v, false
+		}
+		if file, ok := v.Interface().(*api.InputFile); ok {
+			if !file.NeedsUpload(bot.local) {
+				return v, false
+			}
+			name, seen := names[file]
+			if !seen {
+				name = fmt.Sprintf("upload%d", len(uploads))
+				names[file] = name
+				uploads[name] = file
+			}
+			return reflect.ValueOf(file.AttachedAs(name)), true
+		}
+		elem, ok := bot.attachNested(v.Elem(), names, uploads)
+		if !ok {
+			return v, false
+		}
+		ptr := reflect.New(v.Type().Elem())
+		ptr.Elem().Set(elem)
+		return ptr, true
+	case reflect.Interface:
+		if v.IsNil() {
+			return v, false
+		}
+		elem, ok := bot.attachNested(v.Elem(), names, uploads)
+		if !ok {
+			return v, false
+		}
+		iface := reflect.New(v.Type()).Elem()
+		iface.Set(elem)
+		return iface, true
+	case reflect.Struct:
+		var copied reflect.Value
+		for i := 0; i < v.NumField(); i++ {
+			if !v.Type().Field(i).IsExported() {
+				continue
+			}
+			field, ok := bot.attachNested(v.Field(i), names, uploads)
+			if !ok {
+				continue
+			}
+			if !copied.IsValid() {
+				copied = reflect.New(v.Type()).Elem()
+				copied.Set(v)
+			}
+			copied.Field(i).Set(field)
+		}
+		if !copied.IsValid() {
+			return v, false
+		}
+		return copied, true
+	case reflect.Slice, reflect.Array:
+		var copied reflect.Value
+		for i := 0; i < v.Len(); i++ {
+			elem, ok := bot.attachNested(v.Index(i), names, uploads)
+			if !ok {
+				continue
+			}
+			if !copied.IsValid() {
+				if v.Kind() == reflect.Slice {
+					copied = reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+					reflect.Copy(copied, v)
+				} else {
+					copied = reflect.New(v.Type()).Elem()
+					copied.Set(v)
+				}
+			}
+			copied.Index(i).Set(elem)
+		}
+		if !copied.IsValid() {
+			return v, false
+		}
+		return copied, true
+	}
+	return v, false
+}
+
+// multipartFields flattens params into form values.
+// JSON strings are sent unquoted, every other value as raw JSON.
+func multipartFields(params interface{}, uploads map[string]*api.InputFile) (map[string]string, error) {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := map[string]json.RawMessage{}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, err
+	}
+
+	fields := map[string]string{}
+	for name, value := range raw {
+		if _, isUpload := uploads[name]; isUpload {
+			continue
+		}
+		var str string
+		if err := json.Unmarshal(value, &str); err == nil {
+			fields[name] = str
+		} else {
+			fields[name] = string(value)
+		}
+	}
+
+	return fields, nil
+}
+
+func (bot *TgramBot) writeMultipart(form *multipart.Writer, fields map[string]string, uploads map[string]*api.InputFile) error {
+	for name, value := range fields {
+		if err := form.WriteField(name, value); err != nil {
+			return err
+		}
+	}
+
+	for name, file := range uploads {
+		reader := file.Reader
+		if reader == nil {
+			f, err := os.Open(file.Path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			reader = f
+		}
+
+		filename := file.Name
+		if filename == "" && file.Path != "" {
+			filename = filepath.Base(file.Path)
+		}
+		part, err := form.CreateFormFile(name, filename)
+		if err != nil {
+			return err
+		}
+
+		limit := bot.UploadLimit()
+		n, err := io.Copy(part, io.LimitReader(reader, limit+1))
+		if err != nil {
+			return err
+		}
+		if n > limit {
+			return fmt.Errorf("file %s exceeds the %d byte upload limit", file.Name, limit)
+		}
+	}
+
+	return form.Close()
+}
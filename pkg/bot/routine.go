@@ -1,7 +1,9 @@
 package bot
 
 import (
+	"context"
 	"fmt"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
 	"reflect"
 	"strconv"
 )
@@ -11,11 +13,41 @@ type Action struct {
 	Wrapper func(...interface{}) (string, error)
 }
 
+// Routine is a command the bot runs when a message starts with its hook.
+// Description, Usage and Params document the routine for /help and
+// Telegram's command menu. Params names the Action's parameters, and
+// LocalizedDescriptions maps IETF language codes to translated descriptions.
+// Scopes limits the command menus the routine is published in;
+// nil publishes it in every scope the bot publishes commands for.
+// A routine with Subcommands is a command group, e.g. "admin" in
+// "/admin ban 42"; its Access, Throttle and Middleware also apply to
+// every routine below it.
 type Routine struct {
-	Params   []string
-	Action   Action
-	Result   string
-	ErrorMsg string
+	Params                []string
+	Action                Action
+	Result                string
+	ErrorMsg              string
+	Description           string
+	Usage                 string
+	LocalizedDescriptions map[string]string
+	Scopes                []api.BotCommandScope
+	// Aliases are alternative names the routine answers to, e.g. "e" for "echo".
+	Aliases []string
+	// ChatAction is shown while the routine runs when the bot acknowledges
+	// with AckChatAction, e.g. api.ChatActionUploadDocument.
+	ChatAction string
+	// Access restricts who may invoke the routine. Nil allows everyone.
+	Access *AccessPolicy
+	// Throttle rate limits invocations of the routine. Nil means no limit
+	// beyond the bot's own.
+	Throttle *Throttle
+	// Subcommands maps subcommand names to routines. Add to it with AddSubcommand.
+	Subcommands RoutineRegistry
+	// Middleware wraps the execution of the routine and its subcommands,
+	// outermost first.
+	Middleware []Middleware
+
+	parent *Routine
 }
 
 func NewRoutine(action Action) *Routine {
@@ -24,8 +56,50 @@ func NewRoutine(action Action) *Routine {
 	}
 }
 
+// NewGroup constructs a command group with the given description.
+// A group has no Action of its own and only dispatches to its subcommands.
+func NewGroup(description string) *Routine {
+	return &Routine{
+		Description: description,
+		Subcommands: RoutineRegistry{},
+	}
+}
+
+// AddSubcommand registers sub under name below the routine.
+// It returns an error if the name is already taken or sub already has a parent.
+func (cmd *Routine) AddSubcommand(name string, sub *Routine) error {
+	if sub.parent != nil {
+		return fmt.Errorf("couldn't add subcommand: routine already has a parent")
+	}
+	if cmd.Subcommands == nil {
+		cmd.Subcommands = RoutineRegistry{}
+	}
+	if _, taken := cmd.Subcommands[name]; taken {
+		return fmt.Errorf("couldn't add subcommand: name taken")
+	}
+	sub.parent = cmd
+	cmd.Subcommands[name] = sub
+	return nil
+}
+
+// path returns the routine's ancestors and the routine itself, outermost first.
+func (cmd *Routine) path() []*Routine {
+	var path []*Routine
+	for r := cmd; r != nil; r = r.parent {
+		path = append([]*Routine{r}, path...)
+	}
+	return path
+}
+
 func (cmd *Routine) Execute(args []string) (string, error) {
-	castArgs, err := cmd.CastArgs(args)
+	return cmd.ExecuteContext(context.Background(), args)
+}
+
+// ExecuteContext is like Execute, but passes ctx to an Action whose
+// Raw function takes a context.Context as its first parameter.
+// Run uses it to pass each routine the context of the update it handles.
+func (cmd *Routine) ExecuteContext(ctx context.Context, args []string) (string, error) {
+	castArgs, err := cmd.castArgs(ctx, args)
 	if err != nil {
 		return "", err
 	}
@@ -33,41 +107,61 @@ func (cmd *Routine) Execute(args []string) (string, error) {
 	return cmd.Action.Wrapper(castArgs...)
 }
 
+// CastArgs converts args to the parameter types of the Action's Raw function.
+// If Raw takes a context.Context first, context.Background() is passed for it.
 func (cmd *Routine) CastArgs(args []string) ([]interface{}, error) {
+	return cmd.castArgs(context.Background(), args)
+}
+
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+func (cmd *Routine) castArgs(ctx context.Context, args []string) ([]interface{}, error) {
 	fnType := reflect.TypeOf(cmd.Action.Raw)
-	numParams := fnType.NumIn()
-	if numParams != len(args) {
+	offset := 0
+	if fnType.NumIn() > 0 && fnType.In(0) == contextType {
+		offset = 1
+	}
+	numParams := fnType.NumIn() - offset
+	if fnType.IsVariadic() {
+		if len(args) < numParams-1 {
+			return nil, fmt.Errorf("wrong number of args. Given: %d, Takes at least: %d", len(args), numParams-1)
+		}
+	} else if numParams != len(args) {
 		return nil, fmt.Errorf("wrong number of args. Given: %d, Takes: %d", len(args), numParams)
 	}
-	castParams := make([]interface{}, numParams)
-	for i := 0; i < numParams; i++ {
-		paramType := fnType.In(i)
+	castParams := make([]interface{}, offset+len(args))
+	if offset == 1 {
+		castParams[0] = ctx
+	}
+	for i := 0; i < len(args); i++ {
+		var paramType reflect.Type
+		if fnType.IsVariadic() && i >= numParams-1 {
+			paramType = fnType.In(fnType.NumIn() - 1).Elem()
+		} else {
+			paramType = fnType.In(offset + i)
+		}
 		switch paramType.Kind() {
 		case reflect.Int:
 			asInt, err := strconv.Atoi(args[i])
 			if err != nil {
-				fmt.Println("Wrong type")
 				return nil, fmt.Errorf("wrong type of args")
 			}
-			castParams[i] = asInt
+			castParams[offset+i] = asInt
 		case reflect.String:
-			castParams[i] = args[i]
+			castParams[offset+i] = args[i]
 		case reflect.Float64:
 			asFloat, err := strconv.ParseFloat(args[i], 64)
 			if err != nil {
-				fmt.Println("Wrong type")
 				return nil, fmt.Errorf("wrong type of args")
 			}
-			castParams[i] = asFloat
+			castParams[offset+i] = asFloat
 		case reflect.Float32:
 			asFloat, err := strconv.ParseFloat(args[i], 32)
 			if err != nil {
-				fmt.Println("Wrong type")
 				return nil, fmt.Errorf("wrong type of args")
 			}
-			castParams[i] = asFloat
+			castParams[offset+i] = asFloat
 		default:
-			fmt.Println("default hit")
 			return nil, fmt.Errorf("function has unsupported param type")
 		}
 	}
@@ -0,0 +1,329 @@
+package bot
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/cron"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// maxSchedulerSleep bounds how long a Scheduler waits between checks,
+// so jobs still fire on time after the system clock jumps.
+const maxSchedulerSleep = time.Minute
+
+// JobFunc runs a scheduled job registered with HandleJob.
+type JobFunc func(ctx context.Context, job ScheduledJob) error
+
+// ScheduledJob is a job run by a Scheduler.
+// By default it sends Text to ChatID. If Handler is set, the JobFunc
+// registered under that name runs instead and may use ChatID, Text and Data
+// as it likes. Jobs are persisted as JSON, so they refer to handlers by name.
+// Cron and Every are set by the Scheduler method that added the job;
+// a job with neither runs once.
+type ScheduledJob struct {
+	ID      string        `json:"id"`
+	ChatID  int64         `json:"chat_id,omitempty"`
+	Text    string        `json:"text,omitempty"`
+	Handler string        `json:"handler,omitempty"`
+	Data    string        `json:"data,omitempty"`
+	Cron    string        `json:"cron,omitempty"`
+	Every   time.Duration `json:"every,omitempty"`
+	// Next is when the job runs next.
+	Next time.Time `json:"next"`
+}
+
+// Scheduler runs jobs on cron schedules, at fixed intervals or once
+// after a delay. It is started by the bot's Run; bots that do not call Run
+// can start it with Scheduler.Run instead.
+// A Scheduler created with a path saves its jobs there after every change,
+// so schedules survive restarts. Jobs that came due while the bot was down
+// run once when it starts again. It is safe for concurrent use.
+type Scheduler struct {
+	bot      *TgramBot
+	path     string
+	mu       sync.Mutex
+	jobs     map[string]*ScheduledJob
+	crons    map[string]*cron.Schedule
+	handlers map[string]JobFunc
+	running  bool
+	wake     chan struct{}
+}
+
+// NewScheduler constructs a Scheduler persisted to the JSON file at path,
+// loading any jobs saved there before. An empty path keeps jobs in memory.
+// The bot's Run starts the Scheduler.
+func (bot *TgramBot) NewScheduler(path string) (*Scheduler, error) {
+	s := &Scheduler{
+		bot:      bot,
+		path:     path,
+		jobs:     map[string]*ScheduledJob{},
+		crons:    map[string]*cron.Schedule{},
+		handlers: map[string]JobFunc{},
+		wake:     make(chan struct{}, 1),
+	}
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		if err == nil {
+			if err := json.Unmarshal(data, &s.jobs); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for id, job := range s.jobs {
+		if job.Cron == "" {
+			continue
+		}
+		sched, err := cron.Parse(job.Cron)
+		if err != nil {
+			return nil, fmt.Errorf("job %s: %w", id, err)
+		}
+		s.crons[id] = sched
+	}
+
+	bot.schedulers = append(bot.schedulers, s)
+	return s, nil
+}
+
+// HandleJob registers fn as the JobFunc for jobs whose Handler is name.
+func (s *Scheduler) HandleJob(name string, fn JobFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[name] = fn
+}
+
+// Cron adds job to run on the cron schedule expr, e.g. "0 9 * * mon-fri".
+// See package cron for the syntax. Times are in the local time zone.
+// If job has an ID, it replaces any job with that ID, so jobs added at
+// startup are not duplicated by the ones persisted before a restart.
+// A replaced job with the same schedule keeps its next run, so a run that
+// came due while the bot was down still happens.
+// It returns the job as scheduled.
+func (s *Scheduler) Cron(expr string, job ScheduledJob) (ScheduledJob, error) {
+	sched, err := cron.Parse(expr)
+	if err != nil {
+		return ScheduledJob{}, err
+	}
+	job.Cron, job.Every = expr, 0
+	job.Next = sched.Next(time.Now())
+	if job.Next.IsZero() {
+		return ScheduledJob{}, fmt.Errorf("cron expression %q never fires", expr)
+	}
+	return s.add(job, sched)
+}
+
+// Every adds job to run every interval, starting one interval from now.
+// IDs are handled as in Cron.
+func (s *Scheduler) Every(interval time.Duration, job ScheduledJob) (ScheduledJob, error) {
+	if interval <= 0 {
+		return ScheduledJob{}, fmt.Errorf("invalid interval %s", interval)
+	}
+	job.Cron, job.Every = "", interval
+	job.Next = time.Now().Add(interval)
+	return s.add(job, nil)
+}
+
+// After adds job to run once after delay.
+// IDs are handled as in Cron.
+func (s *Scheduler) After(delay time.Duration, job ScheduledJob) (ScheduledJob, error) {
+	return s.At(time.Now().Add(delay), job)
+}
+
+// At adds job to run once at t.
+// IDs are handled as in Cron.
+func (s *Scheduler) At(t time.Time, job ScheduledJob) (ScheduledJob, error) {
+	job.Cron, job.Every = "", 0
+	job.Next = t
+	return s.add(job, nil)
+}
+
+func (s *Scheduler) add(job ScheduledJob, sched *cron.Schedule) (ScheduledJob, error) {
+	if job.Handler == "" && (job.ChatID == 0 || job.Text == "") {
+		return ScheduledJob{}, fmt.Errorf("scheduled job needs a Handler, or a ChatID and Text")
+	}
+	if job.ID == "" {
+		job.ID = newJobID()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if old, ok := s.jobs[job.ID]; ok && sameSchedule(old, &job) {
+		job.Next = old.Next
+	}
+	s.jobs[job.ID] = &job
+	delete(s.crons, job.ID)
+	if sched != nil {
+		s.crons[job.ID] = sched
+	}
+	s.notify()
+	return job, s.save()
+}
+
+// sameSchedule reports whether a and b recur on the same cron schedule or interval.
+func sameSchedule(a, b *ScheduledJob) bool {
+	return (a.Cron != "" || a.Every > 0) && a.Cron == b.Cron && a.Every == b.Every
+}
+
+// Cancel removes a job. Cancelling an unknown job does nothing.
+func (s *Scheduler) Cancel(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.jobs[id]; !ok {
+		return nil
+	}
+	delete(s.jobs, id)
+	delete(s.crons, id)
+	return s.save()
+}
+
+// Jobs returns the scheduled jobs, in the order they run next.
+func (s *Scheduler) Jobs() []ScheduledJob {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jobs := make([]ScheduledJob, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		jobs = append(jobs, *job)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		if jobs[i].Next.Equal(jobs[j].Next) {
+			return jobs[i].ID < jobs[j].ID
+		}
+		return jobs[i].Next.Before(jobs[j].Next)
+	})
+	return jobs
+}
+
+// Run runs jobs as they come due until ctx is done.
+// Each job runs in its own goroutine with a context bounded by handlerTimeout.
+// Calling Run on a Scheduler that is already running returns immediately.
+func (s *Scheduler) Run(ctx context.Context) {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return
+	}
+	s.running = true
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.running = false
+		s.mu.Unlock()
+	}()
+
+	for {
+		timer := time.NewTimer(s.runDue(ctx, time.Now()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-s.wake:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// runDue starts the jobs due at now and moves them to their next run.
+// It returns how long to wait before checking again.
+func (s *Scheduler) runDue(ctx context.Context, now time.Time) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	wait := maxSchedulerSleep
+	changed := false
+	for id, job := range s.jobs {
+		if job.Next.After(now) {
+			wait = min(wait, job.Next.Sub(now))
+			continue
+		}
+
+		go s.runJob(ctx, *job, s.handlers[job.Handler])
+		changed = true
+		switch {
+		case s.crons[id] != nil:
+			job.Next = s.crons[id].Next(now)
+		case job.Every > 0:
+			job.Next = job.Next.Add(job.Every)
+			if !job.Next.After(now) {
+				job.Next = now.Add(job.Every)
+			}
+		default:
+			job.Next = time.Time{}
+		}
+		if job.Next.IsZero() {
+			delete(s.jobs, id)
+			delete(s.crons, id)
+			continue
+		}
+		wait = min(wait, job.Next.Sub(now))
+	}
+
+	if changed {
+		if err := s.save(); err != nil {
+			s.bot.logger.Error("unable to save scheduled jobs", "error", err)
+		}
+	}
+	return wait
+}
+
+func (s *Scheduler) runJob(ctx context.Context, job ScheduledJob, handler JobFunc) {
+	ctx, span := s.bot.tracer.Start(ctx, SpanScheduledJob,
+		Attr("tgram.job_id", job.ID), Attr("tgram.job_handler", job.Handler))
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, handlerTimeout)
+	defer cancel()
+
+	log := s.bot.logger.With("job_id", job.ID)
+	start := time.Now()
+	var err error
+	switch {
+	case job.Handler == "":
+		err = s.bot.SendMsg(ctx, job.Text, job.ChatID)
+	case handler == nil:
+		err = fmt.Errorf("no handler registered for %q", job.Handler)
+	default:
+		err = handler(ctx, job)
+	}
+	if err != nil {
+		span.RecordError(err)
+		log.Error("scheduled job failed", "handler", job.Handler, "duration", time.Since(start), "error", err)
+		return
+	}
+	log.Debug("scheduled job finished", "handler", job.Handler, "duration", time.Since(start))
+}
+
+// notify wakes Run to reconsider when the next job is due.
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// save writes the scheduler's jobs to its file.
+// It must be called with s.mu held.
+func (s *Scheduler) save() error {
+	if s.path == "" {
+		return nil
+	}
+	return writeJSONFile(s.path, s.jobs)
+}
+
+func newJobID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
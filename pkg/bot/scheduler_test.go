@@ -0,0 +1,109 @@
+package bot_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bot"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bottest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// persistJobs writes jobs as a previous run of the bot would have saved them.
+func persistJobs(t *testing.T, path string, jobs ...bot.ScheduledJob) {
+	t.Helper()
+	byID := map[string]bot.ScheduledJob{}
+	for _, job := range jobs {
+		byID[job.ID] = job
+	}
+	data, err := json.Marshal(byID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSchedulerPersistsJobs(t *testing.T) {
+	s := bottest.NewServer()
+	defer s.Close()
+	path := filepath.Join(t.TempDir(), "jobs.json")
+
+	scheduler, err := s.Bot().NewScheduler(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := scheduler.Cron("0 9 * * mon", bot.ScheduledJob{ID: "standup", ChatID: 1, Text: "Standup!"}); err != nil {
+		t.Fatal(err)
+	}
+	reminder, err := scheduler.After(time.Hour, bot.ScheduledJob{ChatID: 2, Text: "Reminder"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := scheduler.Cancel(reminder.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	restarted, err := s.Bot().NewScheduler(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jobs := restarted.Jobs()
+	if len(jobs) != 1 || jobs[0].ID != "standup" || jobs[0].Cron != "0 9 * * mon" || jobs[0].Next.Weekday() != time.Monday {
+		t.Errorf("jobs after restart = %+v, want only the standup", jobs)
+	}
+}
+
+func TestSchedulerCatchesUpAfterRestart(t *testing.T) {
+	s := bottest.NewServer()
+	defer s.Close()
+	path := filepath.Join(t.TempDir(), "jobs.json")
+
+	missed := time.Now().Add(-30 * time.Minute)
+	persistJobs(t, path,
+		bot.ScheduledJob{ID: "digest", ChatID: 1, Text: "Digest", Every: time.Hour, Next: missed},
+		bot.ScheduledJob{ID: "report", ChatID: 1, Text: "Report", Every: time.Hour, Next: missed},
+		bot.ScheduledJob{ID: "once", ChatID: 1, Text: "Once", Next: missed},
+	)
+
+	scheduler, err := s.Bot().NewScheduler(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Jobs are added again at startup, as a bot's main would.
+	if _, err := scheduler.Every(time.Hour, bot.ScheduledJob{ID: "digest", ChatID: 1, Text: "Digest"}); err != nil {
+		t.Fatal(err)
+	}
+	// A changed interval starts over instead of catching up.
+	if _, err := scheduler.Every(2*time.Hour, bot.ScheduledJob{ID: "report", ChatID: 1, Text: "Report"}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go scheduler.Run(ctx)
+
+	s.WaitForCall("sendMessage", 2, 5*time.Second)
+	time.Sleep(50 * time.Millisecond)
+	s.AssertSent(t, 1, "Digest")
+	s.AssertSent(t, 1, "Once")
+	s.AssertNotSent(t, 1, "Report")
+
+	for _, job := range scheduler.Jobs() {
+		switch job.ID {
+		case "digest":
+			if want := missed.Add(time.Hour); !job.Next.Equal(want) {
+				t.Errorf("digest runs next at %v, want %v", job.Next, want)
+			}
+		case "report":
+			if job.Next.Before(time.Now().Add(time.Hour)) {
+				t.Errorf("report runs next at %v, want two hours from its new schedule", job.Next)
+			}
+		default:
+			t.Errorf("job %s is still scheduled", job.ID)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+	"sort"
+	"strings"
+)
+
+// RoutineFunc runs a routine for a message with the parsed arguments.
+// It returns the reply to send.
+type RoutineFunc func(ctx context.Context, msg *api.Message, args []string) (string, error)
+
+// Middleware wraps the execution of a routine, e.g. to log invocations
+// or check preconditions. Calling next runs the rest of the chain.
+type Middleware func(next RoutineFunc) RoutineFunc
+
+// resolve walks the command tree along the words of a message.
+// It returns the deepest matching routine, the registry path naming it,
+// e.g. ["admin", "ban"] for "/adm b" with prefix matching, and the
+// remaining words as arguments. Aliases and prefixes only match in
+// commands, i.e. messages starting with a slash.
+// It returns an *UnknownCommandError if the first word is not a registered hook,
+// and an error if
+// a command group without an Action is missing a valid subcommand.
+// Suggestions are only made for commands.
+func (bot *TgramBot) resolve(msg string) (*Routine, []string, []string, error) {
+	words := strings.Split(msg, " ")
+	command := isCommand(msg)
+	hook, routine, ok := bot.lookup(words[0])
+	if !ok {
+		var suggestion string
+		if command {
+			if suggestion = suggest(bot.Registry, words[0]); suggestion != "" {
+				suggestion = "/" + suggestion
+			}
+		}
+		return nil, nil, nil, &UnknownCommandError{Command: words[0], Suggestion: suggestion}
+	}
+
+	path := []string{hook}
+	n := 1
+	for ; n < len(words); n++ {
+		name, sub, ok := find(routine.Subcommands, words[n], command, command && bot.prefixMatching)
+		if !ok {
+			break
+		}
+		path = append(path, name)
+		routine = sub
+	}
+
+	if routine.Action.Raw == nil && len(routine.Subcommands) > 0 {
+		names := strings.Join(subcommandNames(routine), ", ")
+		if n < len(words) {
+			if suggestion := suggest(routine.Subcommands, words[n]); command && suggestion != "" {
+				return nil, nil, nil, fmt.Errorf("unknown subcommand %q, did you mean %q?", words[n], suggestion)
+			}
+			return nil, nil, nil, fmt.Errorf("unknown subcommand %q, expected one of: %s", words[n], names)
+		}
+		return nil, nil, nil, fmt.Errorf("missing subcommand, expected one of: %s", names)
+	}
+
+	var args []string
+	if n < len(words) {
+		args = words[n:]
+	}
+	return routine, path, args, nil
+}
+
+func subcommandNames(routine *Routine) []string {
+	names := make([]string, 0, len(routine.Subcommands))
+	for name := range routine.Subcommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type messageKey struct{}
+
+// MessageFromContext returns the message that invoked the running routine.
+// It is available to routines whose Action takes a context.Context.
+func MessageFromContext(ctx context.Context) (*api.Message, bool) {
+	msg, ok := ctx.Value(messageKey{}).(*api.Message)
+	return msg, ok
+}
+
+// execute runs routine for msg, wrapped in the Middleware of the routine
+// and of every command group above it. ctx is passed to the routine if
+// its Action takes a context.Context, and carries msg for MessageFromContext.
+func (bot *TgramBot) execute(ctx context.Context, routine *Routine, msg *api.Message, args []string) (string, error) {
+	ctx = context.WithValue(ctx, messageKey{}, msg)
+	run := RoutineFunc(func(ctx context.Context, msg *api.Message, args []string) (string, error) {
+		return routine.ExecuteContext(ctx, args)
+	})
+
+	path := routine.path()
+	for i := len(path) - 1; i >= 0; i-- {
+		for j := len(path[i].Middleware) - 1; j >= 0; j-- {
+			run = path[i].Middleware[j](run)
+		}
+	}
+	return run(ctx, msg, args)
+}
@@ -0,0 +1,98 @@
+package bot_test
+
+import (
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bot"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bottest"
+	"testing"
+	"time"
+)
+
+func echoRoutine() *bot.Routine {
+	echo := func(msg string) (string, error) { return msg, nil }
+	return bot.NewRoutine(bot.Action{
+		Raw: echo,
+		Wrapper: func(i ...interface{}) (string, error) {
+			return echo(i[0].(string))
+		},
+	})
+}
+
+func TestAliasesAndPrefixesMatchOnlyCommands(t *testing.T) {
+	s := bottest.NewServer()
+	defer s.Close()
+	tgBot := s.Bot(bot.WithAck(bot.AckConfig{Mode: bot.AckNone}), bot.WithPrefixMatching())
+
+	echo := echoRoutine()
+	echo.Aliases = []string{"say"}
+	if err := tgBot.RegisterRoutine("echo", echo); err != nil {
+		t.Fatal(err)
+	}
+
+	s.PushMessage(1, "say plain")
+	s.PushMessage(1, "ec plain")
+	s.PushUpdate(api.Update{Message: &api.Message{Chat: &api.Chat{Id: 1}, Photo: []api.PhotoSize{{FileID: "p"}}}})
+	s.PushMessage(1, "/say alias")
+	s.PushMessage(1, "/ec prefix")
+	s.PushMessage(1, "echo hook")
+	go tgBot.Run()
+
+	s.WaitForCall("sendMessage", 3, 5*time.Second)
+	time.Sleep(50 * time.Millisecond)
+	s.AssertSent(t, 1, "alias")
+	s.AssertSent(t, 1, "prefix")
+	s.AssertSent(t, 1, "hook")
+	if sent := s.SentMessages(1); len(sent) != 3 {
+		t.Errorf("sent %d messages, want only the three replies: %+v", len(sent), sent)
+	}
+}
+
+func TestUnknownCommandReplies(t *testing.T) {
+	s := bottest.NewServer()
+	defer s.Close()
+	tgBot := s.Bot(bot.WithAck(bot.AckConfig{Mode: bot.AckNone}))
+	if err := tgBot.RegisterRoutine("echo", echoRoutine()); err != nil {
+		t.Fatal(err)
+	}
+
+	s.PushMessage(1, "ehco not a command")
+	s.PushMessage(1, "/ehco hi")
+	go tgBot.Run()
+
+	s.WaitForCall("sendMessage", 1, 5*time.Second)
+	time.Sleep(50 * time.Millisecond)
+	s.AssertSent(t, 1, `Unknown command "/ehco". Did you mean /echo?`)
+	if sent := s.SentMessages(1); len(sent) != 1 {
+		t.Errorf("sent %d messages, want one reply to the command only: %+v", len(sent), sent)
+	}
+}
+
+func TestDenialsNameCanonicalHook(t *testing.T) {
+	s := bottest.NewServer()
+	defer s.Close()
+
+	denials := make(chan bot.AccessDenial, 1)
+	tgBot := s.Bot(bot.WithAck(bot.AckConfig{Mode: bot.AckNone}), bot.WithPrefixMatching(),
+		bot.WithAccessAudit(func(denial bot.AccessDenial) { denials <- denial }))
+
+	admin := bot.NewGroup("Administration")
+	admin.Access = &bot.AccessPolicy{UserIDs: []int64{99}, DenialMessage: "-"}
+	if err := admin.AddSubcommand("ban", echoRoutine()); err != nil {
+		t.Fatal(err)
+	}
+	if err := tgBot.RegisterRoutine("/admin", admin); err != nil {
+		t.Fatal(err)
+	}
+
+	s.PushMessage(1, "/ADM b 42")
+	go tgBot.Run()
+
+	select {
+	case denial := <-denials:
+		if denial.Hook != "/admin ban" {
+			t.Errorf("denied hook = %q, want the registered path /admin ban", denial.Hook)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("invocation was not denied")
+	}
+}
@@ -0,0 +1,287 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// WaitPlaceholder is replaced by the remaining wait in Throttle replies.
+const WaitPlaceholder = "{wait}"
+
+// Default replies of a Throttle.
+const (
+	DefaultCooldownMessage = "Slow down! Try again in " + WaitPlaceholder + "."
+	DefaultMuteMessage     = "Too many requests. You are muted for " + WaitPlaceholder + "."
+)
+
+// defaultThrottleEvery is the refill interval of a Throttle configured without one.
+const defaultThrottleEvery = time.Second
+
+// ThrottleScope selects what invocations share a token bucket.
+// Scopes combine, e.g. PerUser|PerRoutine limits each user on each routine.
+type ThrottleScope int
+
+const (
+	PerUser ThrottleScope = 1 << iota
+	PerChat
+	PerRoutine
+)
+
+// ThrottleConfig configures a Throttle.
+// Each bucket holds up to Burst invocations and regains one every Every,
+// which defaults to a second.
+// After MuteAfter throttled invocations in a row the bucket's key is muted
+// for MuteFor, and its invocations are dropped without reply; a MuteAfter
+// of 0 disables muting.
+// CooldownMessage and MuteMessage are sent once per throttled streak and
+// per mute; they default to DefaultCooldownMessage and DefaultMuteMessage,
+// and "-" sends nothing. WaitPlaceholder in them is replaced by the
+// remaining wait.
+type ThrottleConfig struct {
+	Scope           ThrottleScope
+	Burst           int
+	Every           time.Duration
+	MuteAfter       int
+	MuteFor         time.Duration
+	CooldownMessage string
+	MuteMessage     string
+}
+
+// ThrottleStats counts the decisions made by a Throttle.
+type ThrottleStats struct {
+	Allowed   uint64
+	Throttled uint64
+	Dropped   uint64
+	Mutes     uint64
+}
+
+type throttleKey struct {
+	userID int64
+	chatID int64
+	hook   string
+}
+
+type bucket struct {
+	tokens     float64
+	last       time.Time
+	strikes    int
+	notified   bool
+	mutedUntil time.Time
+}
+
+// Throttle is a token bucket rate limiter for inbound routine invocations.
+// It is passed to WithThrottle to limit every routine,
+// or set as Routine.Throttle to limit one. It is safe for concurrent use.
+type Throttle struct {
+	config ThrottleConfig
+	// id orders locking when an invocation is checked against several throttles.
+	id uint64
+
+	mu      sync.Mutex
+	buckets map[throttleKey]*bucket
+	checks  int
+
+	allowed   atomic.Uint64
+	throttled atomic.Uint64
+	dropped   atomic.Uint64
+	mutes     atomic.Uint64
+}
+
+var throttleIDs atomic.Uint64
+
+// NewThrottle constructs a Throttle.
+// A zero Scope limits per user, a Burst below 1 is treated as 1,
+// and a zero Every as a second.
+func NewThrottle(config ThrottleConfig) *Throttle {
+	if config.Scope == 0 {
+		config.Scope = PerUser
+	}
+	if config.Burst < 1 {
+		config.Burst = 1
+	}
+	if config.Every <= 0 {
+		config.Every = defaultThrottleEvery
+	}
+	return &Throttle{config: config, id: throttleIDs.Add(1), buckets: map[throttleKey]*bucket{}}
+}
+
+// Stats returns the number of allowed, throttled, dropped and muted events so far.
+func (t *Throttle) Stats() ThrottleStats {
+	return ThrottleStats{
+		Allowed:   t.allowed.Load(),
+		Throttled: t.throttled.Load(),
+		Dropped:   t.dropped.Load(),
+		Mutes:     t.mutes.Load(),
+	}
+}
+
+// allow takes a token for the invocation of hook by msg from every
+// throttle, or from none of them if any refuses it.
+// If the invocation is refused, it returns the reply to send, if any.
+func allow(throttles []*Throttle, hook string, msg *api.Message, now time.Time) (bool, string) {
+	var unique []*Throttle
+	for _, t := range throttles {
+		if t != nil && !containsThrottle(unique, t) {
+			unique = append(unique, t)
+		}
+	}
+	sort.Slice(unique, func(i, j int) bool { return unique[i].id < unique[j].id })
+
+	buckets := make([]*bucket, len(unique))
+	for i, t := range unique {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		b, ok, text := t.check(t.key(hook, msg), now)
+		if !ok {
+			return false, text
+		}
+		buckets[i] = b
+	}
+	for i, t := range unique {
+		t.take(buckets[i])
+	}
+	return true, ""
+}
+
+func containsThrottle(throttles []*Throttle, t *Throttle) bool {
+	for _, other := range throttles {
+		if other == t {
+			return true
+		}
+	}
+	return false
+}
+
+// check refills the bucket of key and reports whether it holds a token.
+// If it does not, the refusal is recorded and the reply to send, if any,
+// is returned. It must be called with t.mu held.
+func (t *Throttle) check(key throttleKey, now time.Time) (*bucket, bool, string) {
+	t.checks++
+	if t.checks%1024 == 0 {
+		t.sweep(now)
+	}
+
+	b, ok := t.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(t.config.Burst), last: now}
+		t.buckets[key] = b
+	}
+
+	if now.Before(b.mutedUntil) {
+		t.dropped.Add(1)
+		return b, false, ""
+	}
+
+	b.tokens += float64(now.Sub(b.last)) / float64(t.config.Every)
+	b.tokens = min(b.tokens, float64(t.config.Burst))
+	b.last = now
+
+	if b.tokens >= 1 {
+		return b, true, ""
+	}
+
+	t.throttled.Add(1)
+	b.strikes++
+	if t.config.MuteAfter > 0 && b.strikes >= t.config.MuteAfter {
+		b.strikes = 0
+		b.notified = false
+		b.mutedUntil = now.Add(t.config.MuteFor)
+		t.mutes.Add(1)
+		return b, false, reply(t.config.MuteMessage, DefaultMuteMessage, t.config.MuteFor)
+	}
+
+	if b.notified {
+		return b, false, ""
+	}
+	b.notified = true
+	wait := time.Duration((1 - b.tokens) * float64(t.config.Every))
+	return b, false, reply(t.config.CooldownMessage, DefaultCooldownMessage, wait)
+}
+
+// take consumes a token from b, which check found to hold one.
+// It must be called with t.mu held.
+func (t *Throttle) take(b *bucket) {
+	b.tokens--
+	b.strikes = 0
+	b.notified = false
+	t.allowed.Add(1)
+}
+
+func (t *Throttle) key(hook string, msg *api.Message) throttleKey {
+	var key throttleKey
+	if t.config.Scope&PerUser != 0 && msg.From != nil {
+		key.userID = msg.From.Id
+	}
+	if t.config.Scope&PerChat != 0 {
+		key.chatID = msg.Chat.Id
+	}
+	if t.config.Scope&PerRoutine != 0 {
+		key.hook = hook
+	}
+	return key
+}
+
+// sweep forgets buckets that have refilled and are not muted.
+// It must be called with t.mu held.
+func (t *Throttle) sweep(now time.Time) {
+	full := time.Duration(t.config.Burst) * t.config.Every
+	for key, b := range t.buckets {
+		if now.Sub(b.last) > full && now.After(b.mutedUntil) {
+			delete(t.buckets, key)
+		}
+	}
+}
+
+func reply(message, fallback string, wait time.Duration) string {
+	switch message {
+	case "-":
+		return ""
+	case "":
+		message = fallback
+	}
+	return strings.ReplaceAll(message, WaitPlaceholder, wait.Round(time.Second).String())
+}
+
+// admit checks a message invoking a routine against the bot's Throttle
+// and those of the routine and its command groups. Refused invocations are answered with the
+// throttle's reply, if any, and must not be processed further.
+// Commands that invoke no routine are checked against the bot's Throttle
+// only, so unknown commands cannot be used to flood the bot. Other
+// messages that do not invoke a registered routine are always admitted.
+// It returns true if the invocation may proceed.
+func (bot *TgramBot) admit(ctx context.Context, msg *api.Message) bool {
+	throttles := []*Throttle{bot.throttle}
+	var hook string
+	routine, names, _, err := bot.resolve(msg.Text)
+	var unknown *UnknownCommandError
+	switch {
+	case err == nil:
+		hook = strings.Join(names, " ")
+		for _, r := range routine.path() {
+			throttles = append(throttles, r.Throttle)
+		}
+	case errors.As(err, &unknown) && !isCommand(msg.Text):
+		return true
+	}
+
+	if allowed, text := allow(throttles, hook, msg, time.Now()); !allowed {
+		bot.logger.Debug("throttled invocation", "routine", hook, "chat_id", msg.Chat.Id)
+		bot.metrics.throttle(hook)
+		if text != "" {
+			done := startWork(ctx)
+			go func() {
+				defer done()
+				bot.reply(ctx, text, msg)
+			}()
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,76 @@
+package bot_test
+
+import (
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bot"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bottest"
+	"testing"
+	"time"
+)
+
+func TestThrottleLimitsUnknownCommands(t *testing.T) {
+	s := bottest.NewServer()
+	defer s.Close()
+	throttle := bot.NewThrottle(bot.ThrottleConfig{Burst: 1, Every: time.Hour, CooldownMessage: "-"})
+	tgBot := s.Bot(bot.WithAck(bot.AckConfig{Mode: bot.AckNone}), bot.WithThrottle(throttle))
+
+	s.PushMessage(1, "/nope")
+	s.PushMessage(1, "/nope")
+	s.PushMessage(1, "plain text")
+	go tgBot.Run()
+
+	s.WaitForCall("sendMessage", 1, 5*time.Second)
+	time.Sleep(50 * time.Millisecond)
+	if sent := s.SentMessages(1); len(sent) != 1 {
+		t.Errorf("sent %d messages, want one unknown command reply: %+v", len(sent), sent)
+	}
+	if stats := throttle.Stats(); stats.Allowed != 1 || stats.Throttled != 1 {
+		t.Errorf("stats = %+v, want one allowed and one throttled command", stats)
+	}
+}
+
+func TestThrottleChecksEveryBucketFirst(t *testing.T) {
+	s := bottest.NewServer()
+	defer s.Close()
+	global := bot.NewThrottle(bot.ThrottleConfig{Burst: 2, Every: time.Hour})
+	tgBot := s.Bot(bot.WithAck(bot.AckConfig{Mode: bot.AckNone}), bot.WithThrottle(global))
+
+	echo := echoRoutine()
+	echo.Throttle = bot.NewThrottle(bot.ThrottleConfig{Burst: 1, Every: time.Hour, CooldownMessage: "-"})
+	if err := tgBot.RegisterRoutine("echo", echo); err != nil {
+		t.Fatal(err)
+	}
+	if err := tgBot.RegisterRoutine("ping", echoRoutine()); err != nil {
+		t.Fatal(err)
+	}
+
+	s.PushMessage(1, "/echo first")
+	s.PushMessage(1, "/echo second")
+	s.PushMessage(1, "/ping pong")
+	go tgBot.Run()
+
+	s.WaitForCall("sendMessage", 2, 5*time.Second)
+	s.AssertSent(t, 1, "first")
+	s.AssertSent(t, 1, "pong")
+	s.AssertNotSent(t, 1, "second")
+	if stats := global.Stats(); stats.Allowed != 2 {
+		t.Errorf("bot throttle stats = %+v, want the refused invocation to keep its token", stats)
+	}
+}
+
+func TestThrottleReplies(t *testing.T) {
+	s := bottest.NewServer()
+	defer s.Close()
+	throttle := bot.NewThrottle(bot.ThrottleConfig{Burst: 1, CooldownMessage: "100% busy, wait {wait}"})
+	tgBot := s.Bot(bot.WithAck(bot.AckConfig{Mode: bot.AckNone}), bot.WithThrottle(throttle))
+	if err := tgBot.RegisterRoutine("echo", echoRoutine()); err != nil {
+		t.Fatal(err)
+	}
+
+	s.PushMessage(1, "/echo a")
+	s.PushMessage(1, "/echo b")
+	go tgBot.Run()
+
+	s.WaitForCall("sendMessage", 2, 5*time.Second)
+	// Every defaults to a second, so the bucket refills instead of staying empty.
+	s.AssertSent(t, 1, "100% busy, wait 1s")
+}
@@ -0,0 +1,87 @@
+package bot
+
+import (
+	"context"
+	"sync"
+)
+
+// Span names used by the bot. API calls are named SpanAPICall followed by
+// the method, e.g. "tgram.api sendMessage".
+const (
+	SpanUpdate    = "tgram.update"
+	SpanHandler   = "tgram.handler"
+	SpanParse     = "tgram.parse"
+	SpanAuthorize = "tgram.authorize"
+	SpanRoutine   = "tgram.routine"
+	SpanAPICall   = "tgram.api"
+
+	SpanScheduledJob = "tgram.job"
+	SpanBroadcast    = "tgram.broadcast"
+)
+
+// Tracer starts the spans the bot records around update handling and API
+// calls. It mirrors the shape of OpenTelemetry's trace.Tracer so that
+// adapters, such as the one in pkg/otelbot, stay thin.
+type Tracer interface {
+	// Start begins a span named name as a child of any span in ctx.
+	// It returns a context carrying the new span.
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+// Span is a single traced operation.
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	// RecordError marks the span as failed with err. It ignores nil errors.
+	RecordError(err error)
+	End()
+}
+
+// Attribute is a key-value pair describing a span.
+// Value is usually a string, int, int64, bool or float64.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Attr returns an Attribute for key and value.
+func Attr(key string, value interface{}) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// noopTracer is the default Tracer. Its spans record nothing.
+type noopTracer struct{}
+
+type noopSpan struct{}
+
+func (noopTracer) Start(ctx context.Context, _ string, _ ...Attribute) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+func (noopSpan) SetAttributes(...Attribute) {}
+func (noopSpan) RecordError(error)          {}
+func (noopSpan) End()                       {}
+
+// Tracer returns the tracer the bot records spans with.
+func (bot *TgramBot) Tracer() Tracer {
+	return bot.tracer
+}
+
+type updateWorkKey struct{}
+
+// withUpdateWork returns a context that tracks the goroutines started
+// for an update with startWork, and a WaitGroup that is done once they finish.
+func withUpdateWork(ctx context.Context) (context.Context, *sync.WaitGroup) {
+	work := &sync.WaitGroup{}
+	return context.WithValue(ctx, updateWorkKey{}, work), work
+}
+
+// startWork registers a goroutine handling the update of ctx.
+// The returned function must be called when it finishes.
+func startWork(ctx context.Context) func() {
+	work, ok := ctx.Value(updateWorkKey{}).(*sync.WaitGroup)
+	if !ok {
+		return func() {}
+	}
+	work.Add(1)
+	return work.Done
+}
@@ -0,0 +1,17 @@
+package bot
+
+import (
+	"context"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+)
+
+// AnswerWebAppQuery sends a message on behalf of the user who opened
+// a Web App, in reply to the query_id found in its validated initData.
+// It returns the api.SentWebAppMessage describing the sent message.
+func (bot *TgramBot) AnswerWebAppQuery(ctx context.Context, params api.AnswerWebAppQueryParams) (*api.SentWebAppMessage, error) {
+	sent := &api.SentWebAppMessage{}
+	if err := bot.Call(ctx, "answerWebAppQuery", params, sent); err != nil {
+		return nil, err
+	}
+	return sent, nil
+}
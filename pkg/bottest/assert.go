@@ -0,0 +1,86 @@
+package bottest
+
+import (
+	"testing"
+	"time"
+)
+
+// SentMessage is a text message the bot sent through sendMessage.
+type SentMessage struct {
+	ChatID int64
+	Text   string
+}
+
+// SentMessages returns every message sent to chatID, in order.
+// If chatID is 0, messages to all chats are returned.
+func (s *Server) SentMessages(chatID int64) []SentMessage {
+	var sent []SentMessage
+	for _, call := range s.Calls("sendMessage") {
+		msg := SentMessage{ChatID: call.Int("chat_id"), Text: call.Param("text")}
+		if chatID == 0 || msg.ChatID == chatID {
+			sent = append(sent, msg)
+		}
+	}
+	return sent
+}
+
+// WaitForCall blocks until method has been called at least n times
+// or the timeout expires, and returns the calls recorded so far.
+// It is useful when the bot makes calls from its own goroutines.
+func (s *Server) WaitForCall(method string, n int, timeout time.Duration) []Call {
+	deadline := time.After(timeout)
+	for {
+		s.mu.Lock()
+		notify := s.notify
+		s.mu.Unlock()
+
+		if calls := s.Calls(method); len(calls) >= n {
+			return calls
+		}
+
+		select {
+		case <-notify:
+		case <-deadline:
+			return s.Calls(method)
+		}
+	}
+}
+
+// AssertCalled fails the test if method was never called.
+func (s *Server) AssertCalled(t testing.TB, method string) {
+	t.Helper()
+	if len(s.Calls(method)) == 0 {
+		t.Errorf("expected a call to %s, got none", method)
+	}
+}
+
+// AssertNotCalled fails the test if method was called.
+func (s *Server) AssertNotCalled(t testing.TB, method string) {
+	t.Helper()
+	if calls := s.Calls(method); len(calls) > 0 {
+		t.Errorf("expected no calls to %s, got %d", method, len(calls))
+	}
+}
+
+// AssertSent fails the test if the bot did not send text to chatID.
+func (s *Server) AssertSent(t testing.TB, chatID int64, text string) {
+	t.Helper()
+	sent := s.SentMessages(chatID)
+	for _, msg := range sent {
+		if msg.Text == text {
+			return
+		}
+	}
+	t.Errorf("expected message %q to chat %d, got %v", text, chatID, sent)
+}
+
+// AssertNotSent fails the test if the bot sent text to chatID.
+func (s *Server) AssertNotSent(t testing.TB, chatID int64, text string) {
+	t.Helper()
+	for _, msg := range s.SentMessages(chatID) {
+		if msg.Text == text {
+			t.Errorf("unexpected message %q to chat %d", text, chatID)
+			return
+		}
+	}
+}
@@ -0,0 +1,566 @@
+// Package bottest provides an in-process fake of the Telegram Bot API
+// for testing bots without talking to api.telegram.org.
+package bottest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bot"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Token is the API key the fake server accepts by default.
+const Token = "123456:TEST-TOKEN"
+
+// Call is a single Bot API request received by the Server.
+// Params holds every request parameter as a string,
+// regardless of whether it was sent in the query string, a form or a JSON body.
+// Non-string JSON values are kept in their raw JSON encoding.
+type Call struct {
+	Method string
+	Params map[string]string
+	Time   time.Time
+}
+
+// Param returns the named request parameter, or "" if it was not sent.
+func (call Call) Param(name string) string {
+	return call.Params[name]
+}
+
+// Int returns the named request parameter parsed as an integer.
+// It returns 0 if the parameter is missing or not a number.
+func (call Call) Int(name string) int64 {
+	n, _ := strconv.ParseInt(call.Params[name], 10, 64)
+	return n
+}
+
+// Decode unmarshals a JSON encoded request parameter into v.
+func (call Call) Decode(name string, v interface{}) error {
+	return json.Unmarshal([]byte(call.Params[name]), v)
+}
+
+// Error is a Bot API error returned by the Server.
+// Returning an *Error from a HandlerFunc makes the server reply with
+// ok=false and the given code and description. A zero Code means 400.
+type Error struct {
+	Code        int
+	Description string
+	RetryAfter  int
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d: %s", e.Code, e.Description)
+}
+
+// HandlerFunc produces the result of a Bot API call.
+// The returned value is JSON encoded into the response's result field.
+type HandlerFunc func(call Call) (interface{}, error)
+
+type scripted struct {
+	result interface{}
+	err    error
+}
+
+// Server is a fake Telegram Bot API server backed by httptest.
+// It records every call, serves injected updates through getUpdates,
+// or POSTs them to the webhook set with setWebhook,
+// and answers methods with scripted responses, custom handlers or defaults.
+type Server struct {
+	*httptest.Server
+	Token string
+	Me    api.User
+
+	mu        sync.Mutex
+	calls     []Call
+	updates   []api.Update
+	nextID    int64
+	nextMsgID int
+	handlers  map[string]HandlerFunc
+	scripts   map[string][]scripted
+	files     map[string][]byte
+	notify    chan struct{}
+	webhook   webhook
+}
+
+// webhook is the state set by setWebhook.
+type webhook struct {
+	url         string
+	secretToken string
+	lastError   string
+}
+
+// NewServer starts a fake Bot API server accepting the default Token.
+// The caller should call Close when finished.
+func NewServer() *Server {
+	s := &Server{
+		Token: Token,
+		Me: api.User{
+			Id:        123456,
+			IsBot:     true,
+			FirstName: "Test Bot",
+			Username:  "test_bot",
+		},
+		nextID:   1,
+		handlers: map[string]HandlerFunc{},
+		scripts:  map[string][]scripted{},
+		files:    map[string][]byte{},
+		notify:   make(chan struct{}),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Bot returns a TgramBot configured to talk to this server.
+// Any extra options are applied after the server's own.
+func (s *Server) Bot(opts ...bot.Option) *bot.TgramBot {
+	opts = append([]bot.Option{
+		bot.WithBaseURL(s.URL),
+		bot.WithHTTPClient(s.Client()),
+	}, opts...)
+	return bot.NewTgramBot(s.Token, opts...)
+}
+
+// Handle overrides the response for a method.
+// The handler is used for every call to the method until Reset is called.
+func (s *Server) Handle(method string, handler HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[method] = handler
+}
+
+// Reply queues a one-shot successful result for the next call to method.
+// Queued responses take precedence over handlers and defaults.
+func (s *Server) Reply(method string, result interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scripts[method] = append(s.scripts[method], scripted{result: result})
+}
+
+// Fail queues a one-shot API error for the next call to method.
+func (s *Server) Fail(method string, code int, description string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := &Error{Code: code, Description: description}
+	s.scripts[method] = append(s.scripts[method], scripted{err: err})
+}
+
+// PushUpdate queues an update to be returned by getUpdates.
+// If a webhook was set with setWebhook, the update is POSTed to it instead
+// before PushUpdate returns, and only stays queued if delivery fails.
+// If the update has no ID, the next sequential ID is assigned.
+// It returns the update as it will be delivered.
+func (s *Server) PushUpdate(update api.Update) api.Update {
+	s.mu.Lock()
+	if update.UpdateId == 0 {
+		update.UpdateId = s.nextID
+	}
+	if update.UpdateId >= s.nextID {
+		s.nextID = update.UpdateId + 1
+	}
+	hook := s.webhook
+	s.mu.Unlock()
+
+	if hook.url != "" {
+		err := deliver(hook, update)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if err == nil {
+			s.webhook.lastError = ""
+			return update
+		}
+		s.webhook.lastError = err.Error()
+		s.updates = append(s.updates, update)
+		return update
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.updates = append(s.updates, update)
+	return update
+}
+
+// deliver POSTs update to the webhook as Telegram would.
+func deliver(hook webhook, update api.Update) error {
+	body, err := json.Marshal(update)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, hook.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if hook.secretToken != "" {
+		req.Header.Set("X-Telegram-Bot-Api-Secret-Token", hook.secretToken)
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("wrong response from the webhook: %s", resp.Status)
+	}
+	return nil
+}
+
+// PushMessage queues a text message update from a private chat.
+// It returns the update as it will be delivered.
+func (s *Server) PushMessage(chatID int64, text string) api.Update {
+	return s.PushUpdate(NewMessageUpdate(chatID, text))
+}
+
+// PushInlineQuery queues an inline query update from the user with the given ID.
+// It returns the update as it will be delivered.
+func (s *Server) PushInlineQuery(userID int64, query, offset string) api.Update {
+	s.mu.Lock()
+	id := fmt.Sprintf("inline-%d", s.nextID)
+	s.mu.Unlock()
+	return s.PushUpdate(api.Update{
+		InlineQuery: &api.InlineQuery{
+			ID:     id,
+			From:   &api.User{Id: userID, FirstName: "Test", Username: "test_user"},
+			Query:  query,
+			Offset: offset,
+		},
+	})
+}
+
+// PostUpdate delivers an update to a webhook handler
+// and returns the recorded response.
+func (s *Server) PostUpdate(handler http.Handler, update api.Update) *httptest.ResponseRecorder {
+	body, err := json.Marshal(update)
+	if err != nil {
+		panic(err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+// AddFile stores a file that getFile can describe and the bot can download.
+// It returns the api.File getFile will report for fileID.
+func (s *Server) AddFile(fileID string, content []byte) api.File {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.files[fileID] = content
+	return fileInfo(fileID, content)
+}
+
+// Calls returns the recorded calls to method.
+// If method is empty, every recorded call is returned.
+func (s *Server) Calls(method string) []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var calls []Call
+	for _, call := range s.calls {
+		if method == "" || call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Reset clears recorded calls, pending updates, handlers and scripted responses.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = nil
+	s.updates = nil
+	s.handlers = map[string]HandlerFunc{}
+	s.scripts = map[string][]scripted{}
+	s.files = map[string][]byte{}
+	s.webhook = webhook{}
+}
+
+// NewMessageUpdate builds an update carrying a text message
+// sent by a user in the private chat with the given ID.
+func NewMessageUpdate(chatID int64, text string) api.Update {
+	user := &api.User{Id: chatID, FirstName: "Test", Username: "test_user"}
+	return api.Update{
+		Message: &api.Message{
+			From: user,
+			Date: int(time.Now().Unix()),
+			Chat: &api.Chat{Id: chatID, FirstName: "Test", Username: "test_user", Type: "private"},
+			Text: text,
+		},
+	}
+}
+
+func fileInfo(fileID string, content []byte) api.File {
+	return api.File{
+		FileID:       fileID,
+		FileUniqueID: "unique-" + fileID,
+		FileSize:     len(content),
+		FilePath:     "documents/" + fileID,
+	}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if len(parts) > 2 && parts[0] == "file" {
+		s.serveFile(w, parts[1:])
+		return
+	}
+	if len(parts) < 2 || !strings.HasPrefix(parts[0], "bot") {
+		writeResponse(w, nil, &Error{Code: http.StatusNotFound, Description: "Not Found"})
+		return
+	}
+	if strings.TrimPrefix(parts[0], "bot") != s.Token {
+		writeResponse(w, nil, &Error{Code: http.StatusUnauthorized, Description: "Unauthorized"})
+		return
+	}
+
+	params, err := parseParams(r)
+	if err != nil {
+		writeResponse(w, nil, &Error{Code: http.StatusBadRequest, Description: err.Error()})
+		return
+	}
+
+	call := Call{Method: parts[len(parts)-1], Params: params, Time: time.Now()}
+	result, err := s.dispatch(call)
+	writeResponse(w, result, err)
+}
+
+func (s *Server) serveFile(w http.ResponseWriter, parts []string) {
+	if strings.TrimPrefix(parts[0], "bot") != s.Token {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	s.mu.Lock()
+	content, ok := s.files[parts[len(parts)-1]]
+	s.mu.Unlock()
+	if !ok {
+		http.NotFound(w, nil)
+		return
+	}
+	_, _ = w.Write(content)
+}
+
+func (s *Server) dispatch(call Call) (interface{}, error) {
+	s.mu.Lock()
+	s.calls = append(s.calls, call)
+	close(s.notify)
+	s.notify = make(chan struct{})
+
+	if queue := s.scripts[call.Method]; len(queue) > 0 {
+		s.scripts[call.Method] = queue[1:]
+		s.mu.Unlock()
+		return queue[0].result, queue[0].err
+	}
+	handler, ok := s.handlers[call.Method]
+	s.mu.Unlock()
+
+	if ok {
+		return handler(call)
+	}
+	return s.defaultResult(call)
+}
+
+func (s *Server) defaultResult(call Call) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch call.Method {
+	case "getMe":
+		return s.Me, nil
+	case "setWebhook":
+		s.webhook = webhook{url: call.Param("url"), secretToken: call.Param("secret_token")}
+		if call.Param("drop_pending_updates") == "true" {
+			s.updates = nil
+		}
+		return true, nil
+	case "deleteWebhook":
+		s.webhook = webhook{}
+		if call.Param("drop_pending_updates") == "true" {
+			s.updates = nil
+		}
+		return true, nil
+	case "getWebhookInfo":
+		return map[string]interface{}{
+			"url":                    s.webhook.url,
+			"has_custom_certificate": false,
+			"pending_update_count":   len(s.updates),
+			"last_error_message":     s.webhook.lastError,
+		}, nil
+	case "getUpdates":
+		if s.webhook.url != "" {
+			return nil, &Error{Code: http.StatusConflict,
+				Description: "Conflict: can't use getUpdates method while webhook is active; use deleteWebhook to delete the webhook first"}
+		}
+		offset := call.Int("offset")
+		var pending []api.Update
+		for _, update := range s.updates {
+			if update.UpdateId >= offset {
+				pending = append(pending, update)
+			}
+		}
+		s.updates = pending
+		if limit := int(call.Int("limit")); limit > 0 && len(pending) > limit {
+			pending = pending[:limit]
+		}
+		if pending == nil {
+			pending = []api.Update{}
+		}
+		return pending, nil
+	case "getFile":
+		content, ok := s.files[call.Param("file_id")]
+		if !ok {
+			return nil, &Error{Code: http.StatusBadRequest, Description: "Bad Request: invalid file_id"}
+		}
+		return fileInfo(call.Param("file_id"), content), nil
+	case "getChat":
+		return api.Chat{Id: call.Int("chat_id"), Type: "private"}, nil
+	case "copyMessage":
+		s.nextMsgID++
+		return api.MessageId{MessageID: s.nextMsgID}, nil
+	case "sendChatAction":
+		return true, nil
+	case "getChatMember":
+		return map[string]interface{}{
+			"status": "member",
+			"user":   api.User{Id: call.Int("user_id"), FirstName: "Test"},
+		}, nil
+	case "getChatAdministrators":
+		return []map[string]interface{}{{"status": "administrator", "user": s.Me}}, nil
+	case "sendPoll":
+		s.nextMsgID++
+		me := s.Me
+		return api.Message{
+			MessageID: s.nextMsgID,
+			From:      &me,
+			Date:      int(time.Now().Unix()),
+			Chat:      &api.Chat{Id: call.Int("chat_id")},
+			Poll:      pollFromCall(fmt.Sprintf("poll-%d", s.nextMsgID), call),
+		}, nil
+	case "stopPoll":
+		poll := pollFromCall(fmt.Sprintf("poll-%d", call.Int("message_id")), call)
+		poll.IsClosed = true
+		return poll, nil
+	}
+
+	if strings.HasPrefix(call.Method, "send") || call.Method == "forwardMessage" {
+		s.nextMsgID++
+		me := s.Me
+		return api.Message{
+			MessageID: s.nextMsgID,
+			From:      &me,
+			Date:      int(time.Now().Unix()),
+			Chat:      &api.Chat{Id: call.Int("chat_id")},
+			Text:      call.Param("text"),
+			Caption:   call.Param("caption"),
+		}, nil
+	}
+	return true, nil
+}
+
+// pollFromCall builds the poll described by sendPoll parameters.
+func pollFromCall(id string, call Call) *api.Poll {
+	poll := &api.Poll{ID: id, Question: call.Param("question"), Type: call.Param("type")}
+	if poll.Type == "" {
+		poll.Type = api.PollTypeRegular
+	}
+	var options []string
+	_ = call.Decode("options", &options)
+	for _, text := range options {
+		poll.Options = append(poll.Options, api.PollOption{Text: text})
+	}
+	return poll
+}
+
+func parseParams(r *http.Request) (map[string]string, error) {
+	params := map[string]string{}
+	for name, values := range r.URL.Query() {
+		params[name] = values[0]
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/json":
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		fields := map[string]json.RawMessage{}
+		if err := json.Unmarshal(body, &fields); err != nil {
+			return nil, fmt.Errorf("invalid JSON body: %w", err)
+		}
+		for name, raw := range fields {
+			var str string
+			if err := json.Unmarshal(raw, &str); err == nil {
+				params[name] = str
+			} else {
+				params[name] = string(raw)
+			}
+		}
+	case "multipart/form-data":
+		if err := r.ParseMultipartForm(32 << 20); err != nil {
+			return nil, err
+		}
+		for name, values := range r.MultipartForm.Value {
+			params[name] = values[0]
+		}
+		for name, files := range r.MultipartForm.File {
+			params[name] = "attach://" + files[0].Filename
+		}
+	case "application/x-www-form-urlencoded":
+		if err := r.ParseForm(); err != nil {
+			return nil, err
+		}
+		for name, values := range r.PostForm {
+			params[name] = values[0]
+		}
+	}
+
+	return params, nil
+}
+
+type response struct {
+	Ok          bool            `json:"ok"`
+	Result      interface{}     `json:"result,omitempty"`
+	ErrorCode   int             `json:"error_code,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Parameters  *responseParams `json:"parameters,omitempty"`
+}
+
+type responseParams struct {
+	RetryAfter int `json:"retry_after,omitempty"`
+}
+
+func writeResponse(w http.ResponseWriter, result interface{}, err error) {
+	resp := response{Ok: true, Result: result}
+	status := http.StatusOK
+	if err != nil {
+		apiErr, ok := err.(*Error)
+		if !ok {
+			apiErr = &Error{Code: http.StatusInternalServerError, Description: err.Error()}
+		}
+		status = apiErr.Code
+		if status == 0 {
+			status = http.StatusBadRequest
+		}
+		resp = response{Ok: false, ErrorCode: status, Description: apiErr.Description}
+		if apiErr.RetryAfter > 0 {
+			resp.Parameters = &responseParams{RetryAfter: apiErr.RetryAfter}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,230 @@
+package bottest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bot"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func echoRoutine() *bot.Routine {
+	echo := func(msg string) (string, error) { return msg, nil }
+	return bot.NewRoutine(bot.Action{
+		Raw: echo,
+		Wrapper: func(i ...interface{}) (string, error) {
+			return echo(i[0].(string))
+		},
+	})
+}
+
+func TestRunRepliesThroughPolling(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	tgBot := s.Bot(bot.WithAck(bot.AckConfig{Mode: bot.AckNone}))
+	if err := tgBot.RegisterRoutine("echo", echoRoutine()); err != nil {
+		t.Fatal(err)
+	}
+	s.PushMessage(42, "echo hello")
+	go tgBot.Run()
+
+	s.WaitForCall("sendMessage", 1, 5*time.Second)
+	s.AssertCalled(t, "getUpdates")
+	s.AssertSent(t, 42, "hello")
+	s.AssertNotSent(t, 42, "echo hello")
+}
+
+func TestGetUpdatesHonoursOffset(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	tgBot := s.Bot()
+
+	first := s.PushMessage(1, "a")
+	s.PushMessage(1, "b")
+
+	updates, err := tgBot.GetUpdates(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updates) != 2 || updates[0].UpdateId != first.UpdateId {
+		t.Fatalf("got %d updates, want 2 starting at %d", len(updates), first.UpdateId)
+	}
+
+	tgBot.Offset = int(updates[1].UpdateId) + 1
+	updates, err = tgBot.GetUpdates(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updates) != 0 {
+		t.Errorf("got %d updates after confirming them, want 0", len(updates))
+	}
+}
+
+func TestScriptedResponses(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	tgBot := s.Bot()
+
+	s.Reply("getMe", api.User{Id: 7, IsBot: true, FirstName: "Scripted"})
+	s.Fail("getMe", http.StatusUnauthorized, "Unauthorized")
+
+	me, err := tgBot.GetMe(context.Background())
+	if err != nil || me.FirstName != "Scripted" {
+		t.Fatalf("GetMe = %v, %v, want the scripted user", me, err)
+	}
+
+	_, err = tgBot.GetMe(context.Background())
+	apiErr, ok := api.AsError(err)
+	if !ok || apiErr.Code != http.StatusUnauthorized {
+		t.Fatalf("GetMe error = %v, want a 401 API error", err)
+	}
+
+	me, err = tgBot.GetMe(context.Background())
+	if err != nil || me.Id != s.Me.Id {
+		t.Fatalf("GetMe = %v, %v, want the default user once scripts are used up", me, err)
+	}
+}
+
+func TestHandlerErrorWithoutCode(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	tgBot := s.Bot()
+
+	s.Handle("sendMessage", func(Call) (interface{}, error) {
+		return nil, &Error{Description: "Bad Request: message text is empty"}
+	})
+
+	err := tgBot.SendMsg(context.Background(), "", 1)
+	apiErr, ok := api.AsError(err)
+	if !ok || apiErr.Code != http.StatusBadRequest {
+		t.Fatalf("SendMsg error = %v, want a 400 API error", err)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	tgBot := s.Bot()
+
+	s.Handle("sendMessage", func(Call) (interface{}, error) {
+		return nil, &Error{Code: http.StatusTooManyRequests, Description: "Too Many Requests", RetryAfter: 3}
+	})
+
+	err := tgBot.SendMsg(context.Background(), "hi", 1)
+	apiErr, ok := api.AsError(err)
+	if !ok || apiErr.RetryAfter() != 3*time.Second {
+		t.Fatalf("SendMsg error = %v, want retry after 3s", err)
+	}
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	tgBot := s.Bot()
+
+	received := make(chan api.Update, 1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Telegram-Bot-Api-Secret-Token") != "s3cret" {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		var update api.Update
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		received <- update
+	}))
+	defer receiver.Close()
+
+	params := map[string]string{"url": receiver.URL, "secret_token": "s3cret"}
+	if err := tgBot.Call(context.Background(), "setWebhook", params, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	pushed := s.PushMessage(5, "via webhook")
+	select {
+	case update := <-received:
+		if update.UpdateId != pushed.UpdateId || update.Message.Text != "via webhook" {
+			t.Errorf("received %+v, want update %d", update, pushed.UpdateId)
+		}
+	default:
+		t.Fatal("update was not delivered to the webhook")
+	}
+
+	_, err := tgBot.GetUpdates(context.Background())
+	if apiErr, ok := api.AsError(err); !ok || apiErr.Code != http.StatusConflict {
+		t.Errorf("GetUpdates error = %v, want 409 while a webhook is set", err)
+	}
+
+	if err := tgBot.Call(context.Background(), "deleteWebhook", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	s.PushMessage(5, "polled")
+	updates, err := tgBot.GetUpdates(context.Background())
+	if err != nil || len(updates) != 1 || updates[0].Message.Text != "polled" {
+		t.Errorf("GetUpdates = %v, %v, want the update pushed after deleteWebhook", updates, err)
+	}
+}
+
+func TestWebhookFailureKeepsUpdatePending(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	tgBot := s.Bot()
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "down", http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	if err := tgBot.Call(context.Background(), "setWebhook", map[string]string{"url": receiver.URL}, nil); err != nil {
+		t.Fatal(err)
+	}
+	s.PushMessage(5, "lost?")
+
+	var info struct {
+		PendingUpdateCount int    `json:"pending_update_count"`
+		LastErrorMessage   string `json:"last_error_message"`
+	}
+	if err := tgBot.Call(context.Background(), "getWebhookInfo", nil, &info); err != nil {
+		t.Fatal(err)
+	}
+	if info.PendingUpdateCount != 1 || info.LastErrorMessage == "" {
+		t.Errorf("webhook info = %+v, want one pending update and an error", info)
+	}
+}
+
+func TestPostUpdate(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	var got api.Update
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+	})
+	rec := s.PostUpdate(handler, NewMessageUpdate(9, "posted"))
+	if rec.Code != http.StatusOK || got.Message == nil || got.Message.Text != "posted" {
+		t.Errorf("PostUpdate delivered %+v with status %d", got, rec.Code)
+	}
+}
+
+func TestDownloadFile(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	tgBot := s.Bot()
+
+	s.AddFile("doc", []byte("file contents"))
+	file, err := tgBot.GetFile(context.Background(), "doc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := tgBot.DownloadFile(context.Background(), file, &buf); err != nil || buf.String() != "file contents" {
+		t.Errorf("DownloadFile wrote %q, %v", buf.String(), err)
+	}
+}
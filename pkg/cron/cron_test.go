@@ -0,0 +1,87 @@
+package cron_test
+
+import (
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/cron"
+	"testing"
+	"time"
+)
+
+// Mon 2024-01-01 10:07 UTC.
+var from = time.Date(2024, 1, 1, 10, 7, 30, 0, time.UTC)
+
+func TestNext(t *testing.T) {
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2024, 1, 1, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, 1, 1, 10, 15, 0, 0, time.UTC)},
+		{"5/20 * * * *", time.Date(2024, 1, 1, 10, 25, 0, 0, time.UTC)},
+		{"0 9-17/4 * * *", time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC)},
+		{"0,30 8 * * *", time.Date(2024, 1, 2, 8, 0, 0, 0, time.UTC)},
+		{"0 9 * * tue", time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)},
+		{"0 9 * * MON-FRI", time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)},
+		{"0 0 1 mar *", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		// 0 and 7 both mean Sunday.
+		{"0 12 * * 0", time.Date(2024, 1, 7, 12, 0, 0, 0, time.UTC)},
+		{"0 12 * * 7", time.Date(2024, 1, 7, 12, 0, 0, 0, time.UTC)},
+		{"0 12 * * 5-7", time.Date(2024, 1, 5, 12, 0, 0, 0, time.UTC)},
+		// With both day fields restricted, either one matching fires (Vixie cron).
+		{"0 0 15 * fri", time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{"0 0 2 * sun", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		// A day field starting with * restricts nothing, so both must match.
+		{"0 0 */2 * fri", time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{"0 0 13 * */5", time.Date(2024, 9, 13, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// February never has 30 days.
+		{"0 0 30 2 *", time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			sched, err := cron.Parse(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := sched.Next(from); !got.Equal(tt.want) {
+				t.Errorf("Next(%v) = %v, want %v", from, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextKeepsLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	sched, err := cron.Parse("0 9 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := time.Date(2024, 1, 2, 9, 0, 0, 0, loc)
+	if got := sched.Next(from.In(loc)); !got.Equal(want) || got.Location() != loc {
+		t.Errorf("Next = %v, want %v", got, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"*/x * * * *",
+		"5-1 * * * *",
+		"* * * foo *",
+		"@never",
+	} {
+		if _, err := cron.Parse(expr); err == nil {
+			t.Errorf("Parse(%q) succeeded, want an error", expr)
+		}
+	}
+}
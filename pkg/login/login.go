@@ -0,0 +1,175 @@
+// Package login verifies sign-ins made with the Telegram Login Widget.
+//
+// After a user authorizes, the widget passes their id, names, photo_url,
+// auth_date and a hash to the site, either as query parameters of the
+// redirect URL or to a JavaScript callback. Verify checks that hash
+// against the bot's token before the data is trusted.
+package login
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrMissingHash is returned when login data carries no hash.
+	ErrMissingHash = errors.New("login: data has no hash")
+	// ErrInvalidHash is returned when login data was not signed with the bot's token.
+	ErrInvalidHash = errors.New("login: data hash mismatch")
+	// ErrExpired is returned when login data is older than the allowed age.
+	ErrExpired = errors.New("login: data expired")
+	// ErrFutureAuthDate is returned when login data is dated further in the
+	// future than MaxClockSkew allows.
+	ErrFutureAuthDate = errors.New("login: data auth_date is in the future")
+)
+
+// MaxClockSkew is how far auth_date may lie ahead of the local clock
+// before Verify rejects login data.
+const MaxClockSkew = time.Minute
+
+// CookieName is the cookie Middleware keeps verified login data in.
+const CookieName = "tg_login"
+
+// User is a Telegram user who signed in with the Login Widget.
+type User struct {
+	ID        int64
+	FirstName string
+	LastName  string
+	Username  string
+	PhotoURL  string
+	AuthDate  time.Time
+}
+
+// Verify checks that login data was signed with token and is no older
+// than maxAge, then parses it. A maxAge of 0 disables the age check,
+// which lets a captured sign-in be replayed forever. An auth_date more
+// than MaxClockSkew ahead of the local clock is rejected either way.
+// It returns ErrInvalidHash, ErrExpired or ErrFutureAuthDate if verification fails.
+func Verify(token string, values url.Values, maxAge time.Duration) (*User, error) {
+	hash := values.Get("hash")
+	if hash == "" {
+		return nil, ErrMissingHash
+	}
+	if !hmac.Equal([]byte(hash), []byte(Sign(token, values))) {
+		return nil, ErrInvalidHash
+	}
+
+	id, err := strconv.ParseInt(values.Get("id"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("login: invalid id: %w", err)
+	}
+	authDate, err := strconv.ParseInt(values.Get("auth_date"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("login: invalid auth_date: %w", err)
+	}
+
+	user := &User{
+		ID:        id,
+		FirstName: values.Get("first_name"),
+		LastName:  values.Get("last_name"),
+		Username:  values.Get("username"),
+		PhotoURL:  values.Get("photo_url"),
+		AuthDate:  time.Unix(authDate, 0),
+	}
+	age := time.Since(user.AuthDate)
+	if age < -MaxClockSkew {
+		return nil, ErrFutureAuthDate
+	}
+	if maxAge > 0 && age > maxAge {
+		return nil, ErrExpired
+	}
+	return user, nil
+}
+
+// Sign computes the hash of login data, ignoring any hash already present.
+// The check string is every key=value pair sorted by key and joined by
+// newlines, signed with HMAC-SHA256 keyed by SHA-256(token).
+func Sign(token string, values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		if key != "hash" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = key + "=" + values.Get(key)
+	}
+
+	secret := sha256.Sum256([]byte(token))
+	mac := hmac.New(sha256.New, secret[:])
+	mac.Write([]byte(strings.Join(pairs, "\n")))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+type contextKey struct{}
+
+// UserFromContext returns the user authenticated by Middleware.
+func UserFromContext(ctx context.Context) (*User, bool) {
+	user, ok := ctx.Value(contextKey{}).(*User)
+	return user, ok
+}
+
+// Middleware authenticates requests with Login Widget data.
+// Data is read from the query string, as sent by the widget's redirect,
+// and is then kept in a cookie so later requests stay signed in until
+// maxAge passes. The cookie holds the signed data itself, so it is
+// verified again on every request.
+// Every query parameter except hash is part of the signed data, so the
+// widget's redirect URL must not carry parameters of its own.
+// Authenticated requests are passed to next with the user available
+// through UserFromContext. Others get 401 Unauthorized.
+func Middleware(token string, maxAge time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			values := r.URL.Query()
+			fromQuery := values.Get("hash") != ""
+			if !fromQuery {
+				cookie, err := r.Cookie(CookieName)
+				if err != nil {
+					http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+					return
+				}
+				if values, err = url.ParseQuery(cookie.Value); err != nil {
+					http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+					return
+				}
+			}
+
+			user, err := Verify(token, values, maxAge)
+			if err != nil {
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+
+			if fromQuery {
+				cookie := &http.Cookie{
+					Name:     CookieName,
+					Value:    values.Encode(),
+					Path:     "/",
+					HttpOnly: true,
+					Secure:   r.TLS != nil,
+					SameSite: http.SameSiteLaxMode,
+				}
+				if maxAge > 0 {
+					cookie.Expires = user.AuthDate.Add(maxAge)
+				}
+				http.SetCookie(w, cookie)
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey{}, user)))
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package login_test
+
+import (
+	"errors"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/login"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const token = "123456:test-token"
+
+// widgetData returns login data signed the way the widget signs it.
+func widgetData(authDate time.Time) url.Values {
+	values := url.Values{
+		"id":         {"42"},
+		"first_name": {"Ada"},
+		"username":   {"ada"},
+		"auth_date":  {strconv.FormatInt(authDate.Unix(), 10)},
+	}
+	values.Set("hash", login.Sign(token, values))
+	return values
+}
+
+func TestVerify(t *testing.T) {
+	user, err := login.Verify(token, widgetData(time.Now()), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.ID != 42 || user.FirstName != "Ada" || user.Username != "ada" {
+		t.Errorf("Verify = %+v, want user 42", user)
+	}
+}
+
+func TestVerifyHashesNewFields(t *testing.T) {
+	values := url.Values{
+		"id":        {"42"},
+		"auth_date": {strconv.FormatInt(time.Now().Unix(), 10)},
+		"is_bot":    {"false"},
+	}
+	values.Set("hash", login.Sign(token, values))
+	if _, err := login.Verify(token, values, time.Hour); err != nil {
+		t.Fatalf("Verify rejected data with a field it does not parse: %v", err)
+	}
+
+	values.Set("is_bot", "true")
+	if _, err := login.Verify(token, values, time.Hour); !errors.Is(err, login.ErrInvalidHash) {
+		t.Errorf("Verify error = %v after changing an unparsed field, want ErrInvalidHash", err)
+	}
+}
+
+func TestVerifyRejects(t *testing.T) {
+	tampered := widgetData(time.Now())
+	tampered.Set("id", "1")
+
+	unsigned := widgetData(time.Now())
+	unsigned.Del("hash")
+
+	tests := []struct {
+		name   string
+		values url.Values
+		want   error
+	}{
+		{"tampered field", tampered, login.ErrInvalidHash},
+		{"missing hash", unsigned, login.ErrMissingHash},
+		{"expired", widgetData(time.Now().Add(-2 * time.Hour)), login.ErrExpired},
+		{"future auth_date", widgetData(time.Now().Add(time.Hour)), login.ErrFutureAuthDate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := login.Verify(token, tt.values, time.Hour); !errors.Is(err, tt.want) {
+				t.Errorf("Verify error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyAllowsClockSkew(t *testing.T) {
+	values := widgetData(time.Now().Add(login.MaxClockSkew / 2))
+	if _, err := login.Verify(token, values, time.Hour); err != nil {
+		t.Errorf("Verify rejected an auth_date within MaxClockSkew: %v", err)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	handler := login.Middleware(token, time.Hour)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, ok := login.UserFromContext(r.Context())
+		if !ok {
+			t.Error("handler reached without a user")
+			return
+		}
+		w.Write([]byte(user.Username))
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?"+widgetData(time.Now()).Encode(), nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != "ada" {
+		t.Fatalf("sign-in got %d %q, want 200 for ada", rec.Code, rec.Body.String())
+	}
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != login.CookieName {
+		t.Fatalf("sign-in set cookies %v, want %s", cookies, login.CookieName)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(cookies[0])
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || rec.Body.String() != "ada" {
+		t.Errorf("request with cookie got %d %q, want 200 for ada", rec.Code, rec.Body.String())
+	}
+
+	tampered := widgetData(time.Now())
+	tampered.Set("username", "mallory")
+	unauthorized := map[string]*http.Request{
+		"no data":  httptest.NewRequest(http.MethodGet, "/", nil),
+		"tampered": httptest.NewRequest(http.MethodGet, "/?"+tampered.Encode(), nil),
+		"forged cookie": func() *http.Request {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.AddCookie(&http.Cookie{Name: login.CookieName, Value: tampered.Encode()})
+			return req
+		}(),
+	}
+	for name, req := range unauthorized {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("%s: got %d, want 401", name, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,293 @@
+// Package metrics implements counters, gauges and histograms exported in
+// the Prometheus text exposition format, without external dependencies.
+//
+// Metrics are created on a Registry, which serves them over HTTP:
+//
+//	reg := metrics.NewRegistry()
+//	requests := reg.Counter("requests_total", "Requests served.", "path")
+//	requests.Inc("/")
+//	http.Handle("/metrics", reg)
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets are the histogram buckets used when none are given,
+// suited to latencies measured in seconds.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+type metric interface {
+	write(b *strings.Builder)
+}
+
+// Registry holds a set of metrics and exports them.
+// It is safe for concurrent use.
+type Registry struct {
+	mu      sync.Mutex
+	names   []string
+	metrics map[string]metric
+}
+
+// NewRegistry constructs an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{metrics: map[string]metric{}}
+}
+
+func (r *Registry) register(name string, m metric) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, taken := r.metrics[name]; taken {
+		panic(fmt.Sprintf("metrics: %s registered twice", name))
+	}
+	r.names = append(r.names, name)
+	r.metrics[name] = m
+}
+
+// Counter registers a counter partitioned by the given label names.
+// It panics if name is already registered.
+func (r *Registry) Counter(name, help string, labels ...string) *Counter {
+	c := &Counter{family: newFamily(name, help, labels), values: map[string]float64{}}
+	r.register(name, c)
+	return c
+}
+
+// Gauge registers a gauge partitioned by the given label names.
+// It panics if name is already registered.
+func (r *Registry) Gauge(name, help string, labels ...string) *Gauge {
+	g := &Gauge{family: newFamily(name, help, labels), values: map[string]float64{}}
+	r.register(name, g)
+	return g
+}
+
+// GaugeFunc registers an unlabeled gauge whose value is read from fn
+// on every export. It panics if name is already registered.
+func (r *Registry) GaugeFunc(name, help string, fn func() float64) {
+	r.register(name, &gaugeFunc{family: newFamily(name, help, nil), fn: fn})
+}
+
+// Histogram registers a histogram with the given upper bucket bounds,
+// partitioned by the given label names. Nil buckets use DefaultBuckets.
+// It panics if name is already registered.
+func (r *Registry) Histogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	h := &Histogram{family: newFamily(name, help, labels), buckets: buckets, series: map[string]*histogramSeries{}}
+	r.register(name, h)
+	return h
+}
+
+// Export renders every metric in the Prometheus text format.
+func (r *Registry) Export() string {
+	r.mu.Lock()
+	names := append([]string(nil), r.names...)
+	metrics := make([]metric, len(names))
+	for i, name := range names {
+		metrics[i] = r.metrics[name]
+	}
+	r.mu.Unlock()
+
+	var b strings.Builder
+	for _, m := range metrics {
+		m.write(&b)
+	}
+	return b.String()
+}
+
+// ServeHTTP serves the exported metrics, making a Registry an http.Handler.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = w.Write([]byte(r.Export()))
+}
+
+// family is the name, help and label names shared by a metric's series.
+type family struct {
+	name   string
+	help   string
+	labels []string
+	mu     sync.Mutex
+}
+
+func newFamily(name, help string, labels []string) family {
+	return family{name: name, help: help, labels: labels}
+}
+
+// key encodes label values as the label set of a series, e.g. `{method="getMe"}`.
+func (f *family) key(values []string) string {
+	if len(values) != len(f.labels) {
+		panic(fmt.Sprintf("metrics: %s takes %d label values, got %d", f.name, len(f.labels), len(values)))
+	}
+	if len(values) == 0 {
+		return ""
+	}
+	pairs := make([]string, len(values))
+	for i, value := range values {
+		pairs[i] = f.labels[i] + `="` + escape(value) + `"`
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func (f *family) header(b *strings.Builder, kind string) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", f.name, f.help, f.name, kind)
+}
+
+func escape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Counter is a monotonically increasing value per label set.
+type Counter struct {
+	family
+	values map[string]float64
+}
+
+// Inc adds one to the series with the given label values.
+func (c *Counter) Inc(labels ...string) {
+	c.Add(1, labels...)
+}
+
+// Add adds v, which must not be negative, to the series with the given label values.
+func (c *Counter) Add(v float64, labels ...string) {
+	if v < 0 {
+		panic("metrics: counters cannot decrease")
+	}
+	key := c.key(labels)
+	c.mu.Lock()
+	c.values[key] += v
+	c.mu.Unlock()
+}
+
+func (c *Counter) write(b *strings.Builder) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.header(b, "counter")
+	for _, key := range sortedKeys(c.values) {
+		fmt.Fprintf(b, "%s%s %s\n", c.name, key, formatFloat(c.values[key]))
+	}
+}
+
+// Gauge is a value per label set that can go up and down.
+type Gauge struct {
+	family
+	values map[string]float64
+}
+
+// Set sets the series with the given label values to v.
+func (g *Gauge) Set(v float64, labels ...string) {
+	key := g.key(labels)
+	g.mu.Lock()
+	g.values[key] = v
+	g.mu.Unlock()
+}
+
+// Add adds v to the series with the given label values.
+func (g *Gauge) Add(v float64, labels ...string) {
+	key := g.key(labels)
+	g.mu.Lock()
+	g.values[key] += v
+	g.mu.Unlock()
+}
+
+func (g *Gauge) write(b *strings.Builder) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.header(b, "gauge")
+	for _, key := range sortedKeys(g.values) {
+		fmt.Fprintf(b, "%s%s %s\n", g.name, key, formatFloat(g.values[key]))
+	}
+}
+
+type gaugeFunc struct {
+	family
+	fn func() float64
+}
+
+func (g *gaugeFunc) write(b *strings.Builder) {
+	g.header(b, "gauge")
+	fmt.Fprintf(b, "%s %s\n", g.name, formatFloat(g.fn()))
+}
+
+type histogramSeries struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// Histogram counts observations into buckets per label set.
+type Histogram struct {
+	family
+	buckets []float64
+	series  map[string]*histogramSeries
+}
+
+// Observe records v in the series with the given label values.
+func (h *Histogram) Observe(v float64, labels ...string) {
+	key := h.key(labels)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.series[key]
+	if !ok {
+		s = &histogramSeries{counts: make([]uint64, len(h.buckets))}
+		h.series[key] = s
+	}
+	for i, bound := range h.buckets {
+		if v <= bound {
+			s.counts[i]++
+		}
+	}
+	s.count++
+	s.sum += v
+}
+
+func (h *Histogram) write(b *strings.Builder) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.header(b, "histogram")
+	for _, key := range sortedKeys(h.series) {
+		s := h.series[key]
+		for i, bound := range h.buckets {
+			fmt.Fprintf(b, "%s_bucket%s %d\n", h.name, withLE(key, formatFloat(bound)), s.counts[i])
+		}
+		fmt.Fprintf(b, "%s_bucket%s %d\n", h.name, withLE(key, "+Inf"), s.count)
+		fmt.Fprintf(b, "%s_sum%s %s\n", h.name, key, formatFloat(s.sum))
+		fmt.Fprintf(b, "%s_count%s %d\n", h.name, key, s.count)
+	}
+}
+
+// withLE adds the le label of a histogram bucket to a series' label set.
+func withLE(key, le string) string {
+	if key == "" {
+		return `{le="` + le + `"}`
+	}
+	return strings.TrimSuffix(key, "}") + `,le="` + le + `"}`
+}
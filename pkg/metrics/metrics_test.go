@@ -0,0 +1,74 @@
+package metrics_test
+
+import (
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/metrics"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestExport(t *testing.T) {
+	reg := metrics.NewRegistry()
+	calls := reg.Counter("calls_total", "Calls made.", "method", "status")
+	queue := reg.Gauge("queue_depth", "Items queued.")
+	reg.GaugeFunc("up", "Whether the bot runs.", func() float64 { return 1 })
+	latency := reg.Histogram("latency_seconds", "Call latency.", []float64{1, 0.1}, "method")
+
+	calls.Inc("sendMessage", "ok")
+	calls.Add(2, "getMe", "ok")
+	calls.Inc("sendMessage", `say "hi"`+"\n")
+	queue.Set(3)
+	queue.Add(-1)
+	latency.Observe(0.05, "getMe")
+	latency.Observe(0.5, "getMe")
+	latency.Observe(2, "getMe")
+
+	want := `# HELP calls_total Calls made.
+# TYPE calls_total counter
+calls_total{method="getMe",status="ok"} 2
+calls_total{method="sendMessage",status="ok"} 1
+calls_total{method="sendMessage",status="say \"hi\"\n"} 1
+# HELP queue_depth Items queued.
+# TYPE queue_depth gauge
+queue_depth 2
+# HELP up Whether the bot runs.
+# TYPE up gauge
+up 1
+# HELP latency_seconds Call latency.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{method="getMe",le="0.1"} 1
+latency_seconds_bucket{method="getMe",le="1"} 2
+latency_seconds_bucket{method="getMe",le="+Inf"} 3
+latency_seconds_sum{method="getMe"} 2.55
+latency_seconds_count{method="getMe"} 3
+`
+	if got := reg.Export(); got != want {
+		t.Errorf("Export() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	reg := metrics.NewRegistry()
+	reg.Counter("hits_total", "Hits.").Inc()
+
+	rec := httptest.NewRecorder()
+	reg.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %q, want the Prometheus text format", ct)
+	}
+	if !strings.Contains(rec.Body.String(), "hits_total 1\n") {
+		t.Errorf("body = %q, want hits_total 1", rec.Body.String())
+	}
+}
+
+func TestLabelCountMismatchPanics(t *testing.T) {
+	reg := metrics.NewRegistry()
+	c := reg.Counter("calls_total", "Calls made.", "method")
+	defer func() {
+		if recover() == nil {
+			t.Error("Inc with a missing label value did not panic")
+		}
+	}()
+	c.Inc()
+}
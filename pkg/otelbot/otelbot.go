@@ -0,0 +1,77 @@
+// Package otelbot adapts the OpenTelemetry tracing API to bot.Tracer.
+// It lives in its own module so that the bot library itself does not
+// depend on OpenTelemetry. Its go.mod replaces the library with the
+// checkout it sits in, so both are always built and tested together.
+//
+//	tgBot := bot.NewTgramBot(key, bot.WithTracer(otelbot.NewTracer(otel.GetTracerProvider())))
+package otelbot
+
+import (
+	"context"
+	"fmt"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bot"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName is the name of the tracer obtained from the TracerProvider.
+const InstrumentationName = "github.com/saltyFamiliar/tgramAPIBotLib/pkg/bot"
+
+type tracer struct {
+	tracer trace.Tracer
+}
+
+// NewTracer returns a bot.Tracer that records spans with a tracer from tp.
+func NewTracer(tp trace.TracerProvider) bot.Tracer {
+	return tracer{tracer: tp.Tracer(InstrumentationName)}
+}
+
+func (t tracer) Start(ctx context.Context, name string, attrs ...bot.Attribute) (context.Context, bot.Span) {
+	ctx, s := t.tracer.Start(ctx, name, trace.WithAttributes(convert(attrs)...))
+	return ctx, span{span: s}
+}
+
+type span struct {
+	span trace.Span
+}
+
+func (s span) SetAttributes(attrs ...bot.Attribute) {
+	s.span.SetAttributes(convert(attrs)...)
+}
+
+// RecordError records err as an exception event and sets the span's status to Error.
+func (s span) RecordError(err error) {
+	if err == nil {
+		return
+	}
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+func (s span) End() {
+	s.span.End()
+}
+
+// convert turns bot attributes into OpenTelemetry attributes.
+// Values of other types are recorded as their fmt.Sprint form.
+func convert(attrs []bot.Attribute) []attribute.KeyValue {
+	kvs := make([]attribute.KeyValue, 0, len(attrs))
+	for _, a := range attrs {
+		switch v := a.Value.(type) {
+		case string:
+			kvs = append(kvs, attribute.String(a.Key, v))
+		case int:
+			kvs = append(kvs, attribute.Int(a.Key, v))
+		case int64:
+			kvs = append(kvs, attribute.Int64(a.Key, v))
+		case bool:
+			kvs = append(kvs, attribute.Bool(a.Key, v))
+		case float64:
+			kvs = append(kvs, attribute.Float64(a.Key, v))
+		default:
+			kvs = append(kvs, attribute.String(a.Key, fmt.Sprint(v)))
+		}
+	}
+	return kvs
+}
@@ -0,0 +1,217 @@
+package otelbot_test
+
+import (
+	"context"
+	"errors"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bot"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bottest"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/otelbot"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"testing"
+	"time"
+)
+
+func newTracer() (bot.Tracer, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	return otelbot.NewTracer(tp), exporter
+}
+
+// waitForSpan polls exporter until a span named name has ended.
+func waitForSpan(t *testing.T, exporter *tracetest.InMemoryExporter, name string) tracetest.SpanStub {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		for _, s := range exporter.GetSpans() {
+			if s.Name == name {
+				return s
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("span %s was not exported", name)
+	return tracetest.SpanStub{}
+}
+
+func spanNamed(spans tracetest.SpanStubs, name string) (tracetest.SpanStub, bool) {
+	for _, s := range spans {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return tracetest.SpanStub{}, false
+}
+
+func TestSpanAttributesAndErrors(t *testing.T) {
+	tracer, exporter := newTracer()
+
+	_, span := tracer.Start(context.Background(), "op", bot.Attr("s", "v"), bot.Attr("n", 3), bot.Attr("d", time.Second))
+	span.SetAttributes(bot.Attr("ok", true))
+	span.RecordError(nil)
+	span.RecordError(errors.New("boom"))
+	span.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("exported %d spans, want 1", len(spans))
+	}
+	got := map[attribute.Key]attribute.Value{}
+	for _, kv := range spans[0].Attributes {
+		got[kv.Key] = kv.Value
+	}
+	if got["s"].AsString() != "v" || got["n"].AsInt64() != 3 || got["d"].AsString() != "1s" || !got["ok"].AsBool() {
+		t.Errorf("attributes = %v", spans[0].Attributes)
+	}
+	if spans[0].Status.Code != codes.Error || len(spans[0].Events) != 1 {
+		t.Errorf("status = %v with %d events, want one recorded error", spans[0].Status, len(spans[0].Events))
+	}
+}
+
+func TestUpdateSpanCoversHandler(t *testing.T) {
+	s := bottest.NewServer()
+	defer s.Close()
+	tracer, exporter := newTracer()
+	tgBot := s.Bot(bot.WithTracer(tracer))
+
+	tgBot.HandlePollAnswer(func(ctx context.Context, _ *api.PollAnswer) error {
+		time.Sleep(50 * time.Millisecond)
+		_, err := tgBot.GetMe(ctx)
+		return err
+	})
+	s.PushUpdate(api.Update{PollAnswer: &api.PollAnswer{PollID: "p", User: &api.User{Id: 1}}})
+	go tgBot.Run()
+
+	update := waitForSpan(t, exporter, bot.SpanUpdate)
+	spans := exporter.GetSpans()
+	handler, ok := spanNamed(spans, bot.SpanHandler)
+	if !ok {
+		t.Fatal("the update span ended before its handler")
+	}
+	if handler.Parent.SpanID() != update.SpanContext.SpanID() {
+		t.Error("the handler span is not a child of the update span")
+	}
+	traced := false
+	for _, s := range spans {
+		// Run also calls getMe to validate the token, outside any update.
+		if s.Name == bot.SpanAPICall+" getMe" && s.Parent.SpanID() == handler.SpanContext.SpanID() {
+			traced = true
+		}
+	}
+	if !traced {
+		t.Error("the handler's API call is not a child of the handler span")
+	}
+	if update.EndTime.Before(handler.EndTime) {
+		t.Errorf("update span ended at %v, before its handler at %v", update.EndTime, handler.EndTime)
+	}
+}
+
+func TestUpdateSpanCoversRoutine(t *testing.T) {
+	s := bottest.NewServer()
+	defer s.Close()
+	tracer, exporter := newTracer()
+	tgBot := s.Bot(bot.WithTracer(tracer), bot.WithAck(bot.AckConfig{Mode: bot.AckNone}))
+
+	slow := func(msg string) (string, error) {
+		time.Sleep(50 * time.Millisecond)
+		return msg, nil
+	}
+	routine := bot.NewRoutine(bot.Action{
+		Raw:     slow,
+		Wrapper: func(i ...interface{}) (string, error) { return slow(i[0].(string)) },
+	})
+	if err := tgBot.RegisterRoutine("echo", routine); err != nil {
+		t.Fatal(err)
+	}
+	s.PushMessage(1, "/echo hi")
+	go tgBot.Run()
+
+	update := waitForSpan(t, exporter, bot.SpanUpdate)
+	routineSpan, ok := spanNamed(exporter.GetSpans(), bot.SpanRoutine)
+	if !ok {
+		t.Fatal("the update span ended before its routine")
+	}
+	if routineSpan.Parent.SpanID() != update.SpanContext.SpanID() {
+		t.Error("the routine span is not a child of the update span")
+	}
+	for _, kv := range routineSpan.Attributes {
+		if kv.Key == "tgram.routine" && kv.Value.AsString() != "echo" {
+			t.Errorf("tgram.routine = %q, want echo", kv.Value.AsString())
+		}
+	}
+}
+
+func TestUpdateSpanCoversReplies(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []bot.Option
+		updates int
+	}{
+		{"acknowledgement", []bot.Option{bot.WithAck(bot.AckConfig{})}, 1},
+		{"throttle reply", []bot.Option{
+			bot.WithAck(bot.AckConfig{Mode: bot.AckNone}),
+			bot.WithThrottle(bot.NewThrottle(bot.ThrottleConfig{Burst: 1, Every: time.Hour})),
+		}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := bottest.NewServer()
+			defer s.Close()
+			s.Handle("sendMessage", func(call bottest.Call) (interface{}, error) {
+				time.Sleep(50 * time.Millisecond)
+				return api.Message{MessageID: 1, Chat: &api.Chat{Id: call.Int("chat_id")}}, nil
+			})
+			tracer, exporter := newTracer()
+			tgBot := s.Bot(append(tt.opts, bot.WithTracer(tracer))...)
+			if err := tgBot.RegisterRoutine("echo", echoRoutine()); err != nil {
+				t.Fatal(err)
+			}
+
+			for i := 0; i < tt.updates; i++ {
+				s.PushMessage(1, "/echo hi")
+			}
+			go tgBot.Run()
+
+			deadline := time.Now().Add(5 * time.Second)
+			ends := map[trace.TraceID]time.Time{}
+			for len(ends) < tt.updates && time.Now().Before(deadline) {
+				for _, span := range exporter.GetSpans() {
+					if span.Name == bot.SpanUpdate {
+						ends[span.SpanContext.TraceID()] = span.EndTime
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+			if len(ends) < tt.updates {
+				t.Fatalf("%d update spans ended, want %d", len(ends), tt.updates)
+			}
+
+			calls := 0
+			for _, span := range exporter.GetSpans() {
+				if span.Name != bot.SpanAPICall+" sendMessage" {
+					continue
+				}
+				calls++
+				if end, ok := ends[span.SpanContext.TraceID()]; ok && span.EndTime.After(end) {
+					t.Error("a sendMessage span ended after its update span")
+				}
+			}
+			if want := len(s.Calls("sendMessage")); calls != want {
+				t.Errorf("%d sendMessage spans ended with their updates, want %d", calls, want)
+			}
+		})
+	}
+}
+
+func echoRoutine() *bot.Routine {
+	echo := func(msg string) (string, error) { return msg, nil }
+	return bot.NewRoutine(bot.Action{
+		Raw:     echo,
+		Wrapper: func(i ...interface{}) (string, error) { return echo(i[0].(string)) },
+	})
+}
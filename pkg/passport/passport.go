@@ -0,0 +1,194 @@
+// Package passport decrypts Telegram Passport data shared with a bot.
+//
+// Telegram encrypts the credentials of each PassportData with the bot's
+// public key. Decrypter unwraps them with the matching RSA private key,
+// and the resulting Credentials hold the per-element secrets needed to
+// decrypt element data and files.
+package passport
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/bot"
+)
+
+// ErrHashMismatch is returned when decrypted data does not match its hash,
+// meaning it was tampered with or decrypted with the wrong secret.
+var ErrHashMismatch = errors.New("passport: data hash mismatch")
+
+// Decrypter decrypts Passport data with the bot's RSA private key.
+type Decrypter struct {
+	key *rsa.PrivateKey
+}
+
+// NewDecrypter constructs a Decrypter for the given private key.
+func NewDecrypter(key *rsa.PrivateKey) *Decrypter {
+	return &Decrypter{key: key}
+}
+
+// ParsePrivateKey parses a PEM encoded PKCS#1 or PKCS#8 RSA private key,
+// such as the one generated with "openssl genrsa 2048".
+func ParsePrivateKey(pemBytes []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, fmt.Errorf("passport: no PEM block found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("passport: unable to parse private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("passport: private key is not RSA")
+	}
+	return key, nil
+}
+
+// DecryptCredentials decrypts the credentials attached to PassportData.
+// It returns an error wrapping ErrHashMismatch if they fail verification.
+func (d *Decrypter) DecryptCredentials(encrypted api.EncryptedCredentials) (*Credentials, error) {
+	encSecret, err := base64.StdEncoding.DecodeString(encrypted.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("passport: invalid credentials secret: %w", err)
+	}
+	secret, err := rsa.DecryptOAEP(sha1.New(), nil, d.key, encSecret, nil)
+	if err != nil {
+		return nil, fmt.Errorf("passport: unable to decrypt credentials secret: %w", err)
+	}
+
+	hash, err := base64.StdEncoding.DecodeString(encrypted.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("passport: invalid credentials hash: %w", err)
+	}
+	data, err := base64.StdEncoding.DecodeString(encrypted.Data)
+	if err != nil {
+		return nil, fmt.Errorf("passport: invalid credentials data: %w", err)
+	}
+
+	plain, err := decrypt(secret, hash, data)
+	if err != nil {
+		return nil, err
+	}
+
+	creds := &Credentials{}
+	if err := json.Unmarshal(plain, creds); err != nil {
+		return nil, fmt.Errorf("passport: unable to unmarshal credentials: %w", err)
+	}
+	return creds, nil
+}
+
+// Decrypt decrypts the credentials and the data of every element of PassportData.
+// Files are not downloaded; use FetchFile with the element's FileCredentials.
+func (d *Decrypter) Decrypt(data *api.PassportData) (*Passport, error) {
+	creds, err := d.DecryptCredentials(data.Credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	passport := &Passport{Nonce: creds.Nonce, Elements: map[string]*Element{}}
+	for _, encrypted := range data.Data {
+		value := creds.SecureData[encrypted.Type_]
+		element := &Element{
+			Type:        encrypted.Type_,
+			PhoneNumber: encrypted.PhoneNumber,
+			Email:       encrypted.Email,
+			Encrypted:   encrypted,
+			Credentials: value,
+		}
+		if encrypted.Data != "" {
+			if value == nil || value.Data == nil {
+				return nil, fmt.Errorf("passport: no credentials for %s data", encrypted.Type_)
+			}
+			if element.Data, err = DecryptData(encrypted.Data, *value.Data); err != nil {
+				return nil, fmt.Errorf("passport: %s: %w", encrypted.Type_, err)
+			}
+		}
+		passport.Elements[encrypted.Type_] = element
+	}
+
+	return passport, nil
+}
+
+// DecryptData decrypts the base64 encoded data of an EncryptedPassportElement.
+func DecryptData(data string, creds DataCredentials) ([]byte, error) {
+	encrypted, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, fmt.Errorf("passport: invalid element data: %w", err)
+	}
+	secret, hash, err := creds.decode()
+	if err != nil {
+		return nil, err
+	}
+	return decrypt(secret, hash, encrypted)
+}
+
+// DecryptFile decrypts the contents of a downloaded PassportFile.
+func DecryptFile(encrypted []byte, creds FileCredentials) ([]byte, error) {
+	secret, hash, err := creds.decode()
+	if err != nil {
+		return nil, err
+	}
+	return decrypt(secret, hash, encrypted)
+}
+
+// FetchFile downloads a PassportFile through the bot and decrypts it.
+func FetchFile(ctx context.Context, b *bot.TgramBot, file api.PassportFile, creds FileCredentials) ([]byte, error) {
+	info, err := b.GetFile(ctx, file.FileID)
+	if err != nil {
+		return nil, err
+	}
+
+	var encrypted bytes.Buffer
+	if err := b.DownloadFile(ctx, info, &encrypted); err != nil {
+		return nil, err
+	}
+	return DecryptFile(encrypted.Bytes(), creds)
+}
+
+// decrypt implements Telegram's Passport data decryption.
+// The AES-256-CBC key and IV are the first 32 and next 16 bytes of
+// SHA-512(secret + hash). The decrypted data must hash to hash with SHA-256,
+// and its first byte gives the length of the random padding to strip.
+func decrypt(secret, hash, data []byte) ([]byte, error) {
+	if len(data) == 0 || len(data)%aes.BlockSize != 0 {
+		return nil, fmt.Errorf("passport: encrypted data is not a multiple of the block size")
+	}
+
+	digest := sha512.Sum512(append(append([]byte{}, secret...), hash...))
+	block, err := aes.NewCipher(digest[:32])
+	if err != nil {
+		return nil, err
+	}
+
+	plain := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, digest[32:48]).CryptBlocks(plain, data)
+
+	sum := sha256.Sum256(plain)
+	if !hmac.Equal(sum[:], hash) {
+		return nil, ErrHashMismatch
+	}
+
+	padding := int(plain[0])
+	if padding < 32 || padding > len(plain) {
+		return nil, fmt.Errorf("passport: invalid padding length %d", padding)
+	}
+	return plain[padding:], nil
+}
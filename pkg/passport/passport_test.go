@@ -0,0 +1,249 @@
+package passport_test
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/passport"
+	"strings"
+	"sync"
+	"testing"
+)
+
+var (
+	keyOnce sync.Once
+	testKey *rsa.PrivateKey
+)
+
+func privateKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	keyOnce.Do(func() {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatal(err)
+		}
+		testKey = key
+	})
+	return testKey
+}
+
+// pad prepends Telegram's random padding to data: between 32 and 255 bytes,
+// the first holding the padding length, making the total a multiple of 16.
+func pad(data []byte) []byte {
+	n := 32 + (16-(len(data)+32)%16)%16
+	padding := make([]byte, n)
+	rand.Read(padding)
+	padding[0] = byte(n)
+	return append(padding, data...)
+}
+
+// seal encrypts padded data the way Telegram does and returns the
+// ciphertext and the data hash.
+func seal(secret, padded []byte) ([]byte, []byte) {
+	hash := sha256.Sum256(padded)
+	digest := sha512.Sum512(append(append([]byte{}, secret...), hash[:]...))
+
+	block, err := aes.NewCipher(digest[:32])
+	if err != nil {
+		panic(err)
+	}
+	encrypted := make([]byte, len(padded))
+	cipher.NewCBCEncrypter(block, digest[32:48]).CryptBlocks(encrypted, padded)
+	return encrypted, hash[:]
+}
+
+func newSecret() []byte {
+	secret := make([]byte, 32)
+	rand.Read(secret)
+	return secret
+}
+
+func b64(b []byte) string {
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// passportData builds PassportData carrying personal details, encrypted
+// for key the way Telegram encrypts it for a bot.
+func passportData(t *testing.T, key *rsa.PublicKey, details passport.PersonalDetails) *api.PassportData {
+	t.Helper()
+	plainDetails, err := json.Marshal(details)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dataSecret := newSecret()
+	encDetails, detailsHash := seal(dataSecret, pad(plainDetails))
+
+	creds, err := json.Marshal(passport.Credentials{
+		SecureData: map[string]*passport.SecureValue{
+			passport.TypePersonalDetails: {Data: &passport.DataCredentials{
+				DataHash: b64(detailsHash),
+				Secret:   b64(dataSecret),
+			}},
+		},
+		Nonce: "nonce-42",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	credsSecret := newSecret()
+	encCreds, credsHash := seal(credsSecret, pad(creds))
+	encSecret, err := rsa.EncryptOAEP(sha1.New(), rand.Reader, key, credsSecret, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &api.PassportData{
+		Data: []api.EncryptedPassportElement{{
+			Type_: passport.TypePersonalDetails,
+			Data:  b64(encDetails),
+			Hash:  b64(detailsHash),
+		}},
+		Credentials: api.EncryptedCredentials{
+			Data:   b64(encCreds),
+			Hash:   b64(credsHash),
+			Secret: b64(encSecret),
+		},
+	}
+}
+
+func TestDecrypt(t *testing.T) {
+	key := privateKey(t)
+	want := passport.PersonalDetails{FirstName: "Ada", LastName: "Lovelace", BirthDate: "10.12.1815"}
+	data := passportData(t, &key.PublicKey, want)
+
+	decrypted, err := passport.NewDecrypter(key).Decrypt(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decrypted.Nonce != "nonce-42" {
+		t.Errorf("nonce = %q, want nonce-42", decrypted.Nonce)
+	}
+	details, err := decrypted.PersonalDetails()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if details == nil || *details != want {
+		t.Errorf("personal details = %+v, want %+v", details, want)
+	}
+}
+
+func TestDecryptRejectsTampering(t *testing.T) {
+	key := privateKey(t)
+	details := passport.PersonalDetails{FirstName: "Ada"}
+
+	tests := []struct {
+		name   string
+		tamper func(data *api.PassportData)
+	}{
+		{"credentials data", func(data *api.PassportData) {
+			data.Credentials.Data = flipByte(data.Credentials.Data, 20)
+		}},
+		{"credentials hash", func(data *api.PassportData) {
+			data.Credentials.Hash = flipByte(data.Credentials.Hash, 0)
+		}},
+		{"element data", func(data *api.PassportData) {
+			data.Data[0].Data = flipByte(data.Data[0].Data, 40)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := passportData(t, &key.PublicKey, details)
+			tt.tamper(data)
+			_, err := passport.NewDecrypter(key).Decrypt(data)
+			if !errors.Is(err, passport.ErrHashMismatch) {
+				t.Errorf("Decrypt error = %v, want ErrHashMismatch", err)
+			}
+		})
+	}
+}
+
+func TestDecryptWithWrongKey(t *testing.T) {
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := passportData(t, &privateKey(t).PublicKey, passport.PersonalDetails{FirstName: "Ada"})
+
+	if _, err := passport.NewDecrypter(other).Decrypt(data); err == nil {
+		t.Error("Decrypt succeeded with the wrong private key")
+	}
+}
+
+func TestDecryptFile(t *testing.T) {
+	secret := newSecret()
+	content := []byte("scan of a passport page")
+	encrypted, hash := seal(secret, pad(content))
+	creds := passport.FileCredentials{FileHash: b64(hash), Secret: b64(secret)}
+
+	plain, err := passport.DecryptFile(encrypted, creds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(plain) != string(content) {
+		t.Errorf("decrypted file = %q, want %q", plain, content)
+	}
+
+	encrypted[len(encrypted)-1] ^= 1
+	if _, err := passport.DecryptFile(encrypted, creds); !errors.Is(err, passport.ErrHashMismatch) {
+		t.Errorf("DecryptFile of tampered file: error = %v, want ErrHashMismatch", err)
+	}
+}
+
+func TestDecryptRejectsShortPadding(t *testing.T) {
+	secret := newSecret()
+	// The hash matches, but the padding length is below Telegram's minimum of 32.
+	padded := make([]byte, 32)
+	padded[0] = 16
+	encrypted, hash := seal(secret, padded)
+
+	_, err := passport.DecryptData(b64(encrypted), passport.DataCredentials{DataHash: b64(hash), Secret: b64(secret)})
+	if err == nil || errors.Is(err, passport.ErrHashMismatch) || !strings.Contains(err.Error(), "padding") {
+		t.Errorf("DecryptData error = %v, want an invalid padding error", err)
+	}
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	key := privateKey(t)
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blocks := []*pem.Block{
+		{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)},
+		{Type: "PRIVATE KEY", Bytes: pkcs8},
+	}
+	for _, block := range blocks {
+		parsed, err := passport.ParsePrivateKey(pem.EncodeToMemory(block))
+		if err != nil {
+			t.Fatalf("%s: %v", block.Type, err)
+		}
+		if !parsed.Equal(key) {
+			t.Errorf("%s: parsed a different key", block.Type)
+		}
+	}
+
+	if _, err := passport.ParsePrivateKey([]byte("not a key")); err == nil {
+		t.Error("ParsePrivateKey accepted input without a PEM block")
+	}
+}
+
+// flipByte flips a bit of the n-th byte of base64 encoded data.
+func flipByte(encoded string, n int) string {
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		panic(err)
+	}
+	raw[n] ^= 0x80
+	return b64(raw)
+}
@@ -0,0 +1,163 @@
+package passport
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/saltyFamiliar/tgramAPIBotLib/api"
+)
+
+// Element types a user can share through Telegram Passport.
+const (
+	TypePersonalDetails       = "personal_details"
+	TypePassport              = "passport"
+	TypeDriverLicense         = "driver_license"
+	TypeIdentityCard          = "identity_card"
+	TypeInternalPassport      = "internal_passport"
+	TypeAddress               = "address"
+	TypeUtilityBill           = "utility_bill"
+	TypeBankStatement         = "bank_statement"
+	TypeRentalAgreement       = "rental_agreement"
+	TypePassportRegistration  = "passport_registration"
+	TypeTemporaryRegistration = "temporary_registration"
+	TypePhoneNumber           = "phone_number"
+	TypeEmail                 = "email"
+)
+
+// Credentials are the decrypted EncryptedCredentials.
+// SecureData maps element types to the secrets of their data and files.
+// Nonce is the payload the bot passed when requesting the data,
+// and should be checked against it.
+type Credentials struct {
+	SecureData map[string]*SecureValue `json:"secure_data"`
+	Nonce      string                  `json:"nonce"`
+}
+
+type SecureValue struct {
+	Data        *DataCredentials  `json:"data,omitempty"`
+	FrontSide   *FileCredentials  `json:"front_side,omitempty"`
+	ReverseSide *FileCredentials  `json:"reverse_side,omitempty"`
+	Selfie      *FileCredentials  `json:"selfie,omitempty"`
+	Translation []FileCredentials `json:"translation,omitempty"`
+	Files       []FileCredentials `json:"files,omitempty"`
+}
+
+type DataCredentials struct {
+	DataHash string `json:"data_hash"`
+	Secret   string `json:"secret"`
+}
+
+type FileCredentials struct {
+	FileHash string `json:"file_hash"`
+	Secret   string `json:"secret"`
+}
+
+func (c DataCredentials) decode() ([]byte, []byte, error) {
+	return decodeSecret(c.Secret, c.DataHash)
+}
+
+func (c FileCredentials) decode() ([]byte, []byte, error) {
+	return decodeSecret(c.Secret, c.FileHash)
+}
+
+func decodeSecret(secret, hash string) ([]byte, []byte, error) {
+	rawSecret, err := base64.StdEncoding.DecodeString(secret)
+	if err != nil {
+		return nil, nil, fmt.Errorf("passport: invalid secret: %w", err)
+	}
+	rawHash, err := base64.StdEncoding.DecodeString(hash)
+	if err != nil {
+		return nil, nil, fmt.Errorf("passport: invalid hash: %w", err)
+	}
+	return rawSecret, rawHash, nil
+}
+
+// Passport is decrypted PassportData.
+// Elements are keyed by their type, e.g. TypePersonalDetails.
+type Passport struct {
+	Nonce    string
+	Elements map[string]*Element
+}
+
+// Element is a decrypted EncryptedPassportElement.
+// Data holds the decrypted JSON for element types that have data.
+// Encrypted and Credentials give access to the element's files.
+type Element struct {
+	Type        string
+	Data        []byte
+	PhoneNumber string
+	Email       string
+	Encrypted   api.EncryptedPassportElement
+	Credentials *SecureValue
+}
+
+type PersonalDetails struct {
+	FirstName            string `json:"first_name"`
+	LastName             string `json:"last_name"`
+	MiddleName           string `json:"middle_name,omitempty"`
+	BirthDate            string `json:"birth_date"`
+	Gender               string `json:"gender"`
+	CountryCode          string `json:"country_code"`
+	ResidenceCountryCode string `json:"residence_country_code"`
+	FirstNameNative      string `json:"first_name_native,omitempty"`
+	LastNameNative       string `json:"last_name_native,omitempty"`
+	MiddleNameNative     string `json:"middle_name_native,omitempty"`
+}
+
+type ResidentialAddress struct {
+	StreetLine1 string `json:"street_line1"`
+	StreetLine2 string `json:"street_line2,omitempty"`
+	City        string `json:"city"`
+	State       string `json:"state,omitempty"`
+	CountryCode string `json:"country_code"`
+	PostCode    string `json:"post_code"`
+}
+
+type IDDocumentData struct {
+	DocumentNo string `json:"document_no"`
+	ExpiryDate string `json:"expiry_date,omitempty"`
+}
+
+// PersonalDetails returns the user's decrypted personal details,
+// or nil if they were not shared.
+func (p *Passport) PersonalDetails() (*PersonalDetails, error) {
+	details := &PersonalDetails{}
+	ok, err := p.decode(TypePersonalDetails, details)
+	if !ok {
+		return nil, err
+	}
+	return details, nil
+}
+
+// Address returns the user's decrypted residential address,
+// or nil if it was not shared.
+func (p *Passport) Address() (*ResidentialAddress, error) {
+	address := &ResidentialAddress{}
+	ok, err := p.decode(TypeAddress, address)
+	if !ok {
+		return nil, err
+	}
+	return address, nil
+}
+
+// IDDocument returns the decrypted data of an identity document
+// of the given type, e.g. TypePassport, or nil if it was not shared.
+func (p *Passport) IDDocument(docType string) (*IDDocumentData, error) {
+	doc := &IDDocumentData{}
+	ok, err := p.decode(docType, doc)
+	if !ok {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func (p *Passport) decode(elementType string, v interface{}) (bool, error) {
+	element, ok := p.Elements[elementType]
+	if !ok || element.Data == nil {
+		return false, nil
+	}
+	if err := json.Unmarshal(element.Data, v); err != nil {
+		return false, fmt.Errorf("passport: unable to unmarshal %s: %w", elementType, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,176 @@
+// Package webapp authenticates data sent by Telegram Mini Apps.
+//
+// A Mini App receives Telegram.WebApp.initData, a query string signed
+// with a key derived from the bot's token. The app forwards it to its
+// backend, which calls Validate before trusting any of its fields.
+package webapp
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrMissingHash is returned when initData carries no hash.
+	ErrMissingHash = errors.New("webapp: init data has no hash")
+	// ErrInvalidHash is returned when initData was not signed with the bot's token.
+	ErrInvalidHash = errors.New("webapp: init data hash mismatch")
+	// ErrExpired is returned when initData is older than the allowed age.
+	ErrExpired = errors.New("webapp: init data expired")
+	// ErrFutureAuthDate is returned when initData is dated further in the
+	// future than MaxClockSkew allows.
+	ErrFutureAuthDate = errors.New("webapp: init data auth_date is in the future")
+)
+
+// MaxClockSkew is how far auth_date may lie ahead of the local clock
+// before Validate rejects initData.
+const MaxClockSkew = time.Minute
+
+// User is a Telegram user as described in Web App initData.
+type User struct {
+	ID                    int64  `json:"id"`
+	IsBot                 bool   `json:"is_bot,omitempty"`
+	FirstName             string `json:"first_name"`
+	LastName              string `json:"last_name,omitempty"`
+	Username              string `json:"username,omitempty"`
+	LanguageCode          string `json:"language_code,omitempty"`
+	IsPremium             bool   `json:"is_premium,omitempty"`
+	AddedToAttachmentMenu bool   `json:"added_to_attachment_menu,omitempty"`
+	AllowsWriteToPm       bool   `json:"allows_write_to_pm,omitempty"`
+	PhotoURL              string `json:"photo_url,omitempty"`
+}
+
+// Chat is the chat a Web App was opened from via the attachment menu.
+type Chat struct {
+	ID       int64  `json:"id"`
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Username string `json:"username,omitempty"`
+	PhotoURL string `json:"photo_url,omitempty"`
+}
+
+// InitData is validated Web App initData.
+// QueryID is passed to bot.AnswerWebAppQuery to reply on the user's behalf.
+type InitData struct {
+	QueryID      string
+	User         *User
+	Receiver     *User
+	Chat         *Chat
+	ChatType     string
+	ChatInstance string
+	StartParam   string
+	CanSendAfter time.Duration
+	AuthDate     time.Time
+	Hash         string
+}
+
+// Validate checks that initData was signed with token and is no older
+// than maxAge, then parses it. A maxAge of 0 disables the age check,
+// which leaves the backend open to replayed data. An auth_date more than
+// MaxClockSkew ahead of the local clock is rejected either way.
+// It returns ErrInvalidHash, ErrExpired or ErrFutureAuthDate if validation fails.
+func Validate(token, initData string, maxAge time.Duration) (*InitData, error) {
+	values, err := url.ParseQuery(initData)
+	if err != nil {
+		return nil, fmt.Errorf("webapp: malformed init data: %w", err)
+	}
+
+	hash := values.Get("hash")
+	if hash == "" {
+		return nil, ErrMissingHash
+	}
+	expected := Sign(token, values)
+	if !hmac.Equal([]byte(hash), []byte(expected)) {
+		return nil, ErrInvalidHash
+	}
+
+	data, err := parse(values)
+	if err != nil {
+		return nil, err
+	}
+	age := time.Since(data.AuthDate)
+	if age < -MaxClockSkew {
+		return nil, ErrFutureAuthDate
+	}
+	if maxAge > 0 && age > maxAge {
+		return nil, ErrExpired
+	}
+	return data, nil
+}
+
+// Sign computes the hash of initData values, ignoring any hash already present.
+// The check string is every key=value pair sorted by key and joined by
+// newlines, signed with HMAC-SHA256 keyed by HMAC-SHA256("WebAppData", token).
+func Sign(token string, values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		if key != "hash" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = key + "=" + values.Get(key)
+	}
+
+	secret := hmac.New(sha256.New, []byte("WebAppData"))
+	secret.Write([]byte(token))
+	mac := hmac.New(sha256.New, secret.Sum(nil))
+	mac.Write([]byte(strings.Join(pairs, "\n")))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func parse(values url.Values) (*InitData, error) {
+	data := &InitData{
+		QueryID:      values.Get("query_id"),
+		ChatType:     values.Get("chat_type"),
+		ChatInstance: values.Get("chat_instance"),
+		StartParam:   values.Get("start_param"),
+		Hash:         values.Get("hash"),
+	}
+
+	authDate, err := strconv.ParseInt(values.Get("auth_date"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("webapp: invalid auth_date: %w", err)
+	}
+	data.AuthDate = time.Unix(authDate, 0)
+
+	if after := values.Get("can_send_after"); after != "" {
+		seconds, err := strconv.Atoi(after)
+		if err != nil {
+			return nil, fmt.Errorf("webapp: invalid can_send_after: %w", err)
+		}
+		data.CanSendAfter = time.Duration(seconds) * time.Second
+	}
+
+	fields := []struct {
+		name string
+		dest interface{}
+	}{
+		{"user", &data.User},
+		{"receiver", &data.Receiver},
+		{"chat", &data.Chat},
+	}
+	for _, field := range fields {
+		raw := values.Get(field.name)
+		if raw == "" {
+			continue
+		}
+		if err := json.Unmarshal([]byte(raw), field.dest); err != nil {
+			return nil, fmt.Errorf("webapp: invalid %s: %w", field.name, err)
+		}
+	}
+
+	return data, nil
+}
@@ -0,0 +1,75 @@
+package webapp_test
+
+import (
+	"errors"
+	"github.com/saltyFamiliar/tgramAPIBotLib/pkg/webapp"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const token = "123456:test-token"
+
+// initData returns signed initData authenticated at authDate.
+func initData(authDate time.Time) url.Values {
+	values := url.Values{
+		"query_id":  {"AAH"},
+		"user":      {`{"id":42,"first_name":"Ada","username":"ada"}`},
+		"auth_date": {strconv.FormatInt(authDate.Unix(), 10)},
+	}
+	values.Set("hash", webapp.Sign(token, values))
+	return values
+}
+
+func TestValidate(t *testing.T) {
+	data, err := webapp.Validate(token, initData(time.Now()).Encode(), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.QueryID != "AAH" || data.User == nil || data.User.ID != 42 || data.User.Username != "ada" {
+		t.Errorf("Validate = %+v, want query AAH from user 42", data)
+	}
+}
+
+func TestValidateRejects(t *testing.T) {
+	tampered := initData(time.Now())
+	tampered.Set("user", `{"id":1,"first_name":"Mallory"}`)
+
+	unsigned := initData(time.Now())
+	unsigned.Del("hash")
+
+	tests := []struct {
+		name   string
+		values url.Values
+		token  string
+		want   error
+	}{
+		{"tampered field", tampered, token, webapp.ErrInvalidHash},
+		{"other token", initData(time.Now()), "654321:other", webapp.ErrInvalidHash},
+		{"missing hash", unsigned, token, webapp.ErrMissingHash},
+		{"expired", initData(time.Now().Add(-2 * time.Hour)), token, webapp.ErrExpired},
+		{"future auth_date", initData(time.Now().Add(time.Hour)), token, webapp.ErrFutureAuthDate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := webapp.Validate(tt.token, tt.values.Encode(), time.Hour); !errors.Is(err, tt.want) {
+				t.Errorf("Validate error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAllowsClockSkew(t *testing.T) {
+	values := initData(time.Now().Add(webapp.MaxClockSkew / 2)).Encode()
+	if _, err := webapp.Validate(token, values, time.Hour); err != nil {
+		t.Errorf("Validate rejected an auth_date within MaxClockSkew: %v", err)
+	}
+}
+
+func TestValidateWithoutMaxAge(t *testing.T) {
+	values := initData(time.Now().Add(-30 * 24 * time.Hour)).Encode()
+	if _, err := webapp.Validate(token, values, 0); err != nil {
+		t.Errorf("Validate with maxAge 0 = %v, want no age check", err)
+	}
+}